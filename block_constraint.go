@@ -101,6 +101,8 @@ type BlockConstraint struct {
 	Attributes  ConstraintMap      `json:"attributes,omitempty"`
 	Data        ConstraintMap      `json:"data,omitempty"`
 	Deprecated  *Deprecation       `json:"deprecated,omitempty"`
+
+	spec specRef
 }
 
 // IsNoop returns true if the constraint doesn't affect anything.
@@ -128,6 +130,7 @@ func (bc BlockConstraint) Copy() *BlockConstraint {
 		Attributes:  bc.Attributes.Copy(),
 		Data:        bc.Data.Copy(),
 		Deprecated:  deprCopy(bc.Deprecated),
+		spec:        bc.spec,
 	}
 }
 
@@ -385,6 +388,14 @@ func setBlockAttribute(block *newsdoc.Block, name string, value string) {
 // DescribeCountConstraint returns a human readable (english) description of the
 // count contstraint for the block constraint.
 func (bc BlockConstraint) DescribeCountConstraint(kind BlockKind) string {
+	return bc.DescribeCountConstraintWithFormats(kind, nil)
+}
+
+// DescribeCountConstraintWithFormats describes the count constraint, using the
+// registry to describe custom formats in match expressions.
+func (bc BlockConstraint) DescribeCountConstraintWithFormats(
+	kind BlockKind, formats *FormatRegistry,
+) string {
 	var s strings.Builder
 
 	s.WriteString("there must be ")
@@ -412,7 +423,7 @@ func (bc BlockConstraint) DescribeCountConstraint(kind BlockKind) string {
 
 	if len(bc.Match.Keys) > 0 {
 		s.WriteString(" where ")
-		s.WriteString(bc.Match.Requirements())
+		s.WriteString(bc.Match.RequirementsWithFormats(formats))
 	}
 
 	if bc.Declares != nil {
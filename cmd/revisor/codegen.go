@@ -0,0 +1,70 @@
+package main
+
+import (
+	"io"
+
+	"github.com/ttab/revisor/codegen"
+	"github.com/urfave/cli/v2"
+)
+
+func codegenCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "codegen",
+		Usage: "generates code for the document types of constraint sets",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "ts",
+				Usage: "generates TypeScript declarations",
+				Flags: append([]cli.Flag{
+					&cli.PathFlag{
+						Name:  "out",
+						Usage: "Write the declarations to a file instead of stdout",
+					},
+				}, specFlags...),
+				Action: codegenTSAction,
+			},
+			{
+				Name:  "go",
+				Usage: "generates Go types and helpers",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "package",
+						Usage: "Name of the generated package",
+						Value: "documents",
+					},
+					&cli.PathFlag{
+						Name:  "out",
+						Usage: "Write the code to a file instead of stdout",
+					},
+				}, specFlags...),
+				Action: codegenGoAction,
+			},
+		},
+	}
+}
+
+func codegenTSAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c.Path("out"), func(w io.Writer) error {
+		return codegen.TypeScript(w, validator)
+	})
+}
+
+func codegenGoAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	opts := codegen.GoOptions{
+		Package: c.String("package"),
+	}
+
+	return writeOutput(c.Path("out"), func(w io.Writer) error {
+		return codegen.Go(w, validator, opts)
+	})
+}
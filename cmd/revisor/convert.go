@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+	"github.com/urfave/cli/v2"
+)
+
+func convertCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "convert",
+		Usage:     "converts a constraint set between JSON and YAML",
+		ArgsUsage: "<constraint set file>",
+		Description: `The input format is decided by the file extension (.json, .yaml or .yml).
+The constraint set is validated before it's converted. The output format
+defaults to the format indicated by --out, or the opposite of the input
+format.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "Output format, \"json\" or \"yaml\"",
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "Output file, defaults to stdout",
+			},
+		},
+		Action: convertAction,
+	}
+}
+
+func convertAction(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("expected exactly one constraint set file")
+	}
+
+	input := c.Args().First()
+	from := fileFormat(input)
+
+	to := c.String("to")
+
+	switch {
+	case to != "":
+	case c.String("out") != "":
+		to = fileFormat(c.String("out"))
+	case from == "yaml":
+		to = "json"
+	default:
+		to = "yaml"
+	}
+
+	if to != "json" && to != "yaml" {
+		return fmt.Errorf("unknown output format %q", to)
+	}
+
+	if to == from {
+		return fmt.Errorf("the input is already %s", to)
+	}
+
+	// Make sure that we have a valid constraint set before converting.
+	_, err := revisor.LoadConstraintSetFiles(input)
+	if err != nil {
+		return fmt.Errorf("load constraint set: %w", err)
+	}
+
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("read constraint set: %w", err)
+	}
+
+	var out []byte
+
+	switch to {
+	case "json":
+		doc, err := internal.ParseYAML(data)
+		if err != nil {
+			return fmt.Errorf("convert to JSON: %w", err)
+		}
+
+		out, err = internal.EncodeJSON(doc)
+		if err != nil {
+			return fmt.Errorf("convert to JSON: %w", err)
+		}
+	case "yaml":
+		out, err = internal.JSONToYAML(data)
+		if err != nil {
+			return fmt.Errorf("convert to YAML: %w", err)
+		}
+	}
+
+	return writeOutput(c.String("out"), func(w io.Writer) error {
+		_, err := w.Write(out)
+		if err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func fileFormat(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	}
+
+	return ""
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+	"github.com/urfave/cli/v2"
+)
+
+func coverageCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "coverage",
+		Usage:     "reports which constraints a set of documents exercise",
+		ArgsUsage: "[document files or glob patterns...]",
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "unused",
+				Usage: "Only list constraints that never were matched",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output the report as JSON",
+			},
+		}, specFlags...),
+		Action: coverageAction,
+	}
+}
+
+func coverageAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	paths, err := expandGlobs(c.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return errors.New("no documents to check")
+	}
+
+	collector := revisor.NewCoverageCollector(validator)
+
+	for _, p := range paths {
+		var doc newsdoc.Document
+
+		err := internal.UnmarshalFile(p, &doc)
+		if err != nil {
+			return fmt.Errorf("load document %q: %w", p, err)
+		}
+
+		_, err = validator.ValidateDocument(c.Context, &doc,
+			revisor.WithCoverage(collector))
+		if err != nil {
+			return fmt.Errorf("validate document %q: %w", p, err)
+		}
+	}
+
+	entries := collector.Entries()
+
+	if c.Bool("unused") {
+		entries = collector.Unused()
+	}
+
+	if c.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+
+		enc.SetIndent("", "  ")
+
+		err := enc.Encode(entries)
+		if err != nil {
+			return fmt.Errorf("encode report: %w", err)
+		}
+
+		return nil
+	}
+
+	return writeCoverageReport(os.Stdout, collector.Entries(), entries)
+}
+
+func writeCoverageReport(
+	out io.Writer, all []revisor.CoverageEntry, entries []revisor.CoverageEntry,
+) error {
+	var used int
+
+	for _, e := range all {
+		if e.Count > 0 {
+			used++
+		}
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	var currentSet string
+
+	for i, e := range entries {
+		if i == 0 || e.ConstraintSet != currentSet {
+			currentSet = e.ConstraintSet
+
+			_, _ = fmt.Fprintf(tw, "\n%s\n", currentSet)
+		}
+
+		_, _ = fmt.Fprintf(tw, "\t%d\t%s\t%s\t%s\n",
+			e.Count, e.Kind, e.Path, e.Description)
+	}
+
+	var pct float64
+
+	if len(all) > 0 {
+		pct = float64(used) / float64(len(all)) * 100
+	}
+
+	_, _ = fmt.Fprintf(tw, "\n%d of %d constraints matched (%.1f%%)\n",
+		used, len(all), pct)
+
+	err := tw.Flush()
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
+
+func expandGlobs(patterns []string) ([]string, error) {
+	var paths []string
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w",
+				pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files matched %q", pattern)
+		}
+
+		paths = append(paths, matches...)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+	"github.com/urfave/cli/v2"
+)
+
+func deprecationsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "deprecations",
+		Usage:     "reports how a set of documents use deprecated constraints",
+		ArgsUsage: "[document files or glob patterns...]",
+		Flags: append([]cli.Flag{
+			&cli.IntFlag{
+				Name:  "examples",
+				Usage: "Number of example document UUIDs to list per deprecation",
+				Value: 5,
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output the report as JSON",
+			},
+		}, specFlags...),
+		Action: deprecationsAction,
+	}
+}
+
+func deprecationsAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	paths, err := expandGlobs(c.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return errors.New("no documents to check")
+	}
+
+	collector := revisor.NewDeprecationCollector(
+		revisor.WithDeprecationExamples(c.Int("examples")))
+
+	for _, p := range paths {
+		var doc newsdoc.Document
+
+		err := internal.UnmarshalFile(p, &doc)
+		if err != nil {
+			return fmt.Errorf("load document %q: %w", p, err)
+		}
+
+		_, err = collector.ValidateDocument(c.Context, validator, &doc, nil)
+		if err != nil {
+			return fmt.Errorf("validate document %q: %w", p, err)
+		}
+	}
+
+	report := collector.Report()
+
+	if c.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+
+		enc.SetIndent("", "  ")
+
+		err := enc.Encode(report)
+		if err != nil {
+			return fmt.Errorf("encode report: %w", err)
+		}
+
+		return nil
+	}
+
+	return writeDeprecationReport(os.Stdout, report)
+}
+
+func writeDeprecationReport(out io.Writer, report revisor.DeprecationReport) error {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	for _, u := range report.Deprecations {
+		_, _ = fmt.Fprintf(tw, "\n%s: %d uses in %d documents",
+			u.Label, u.Uses, u.Documents)
+
+		if u.Sunset != nil {
+			_, _ = fmt.Fprintf(tw, ", enforced from %s",
+				u.Sunset.Format(time.RFC3339))
+		}
+
+		_, _ = fmt.Fprintln(tw)
+
+		for _, t := range u.DocumentTypes {
+			_, _ = fmt.Fprintf(tw, "\t%s\t%d documents\t%d uses\n",
+				t.Type, t.Documents, t.Uses)
+		}
+
+		if len(u.Examples) > 0 {
+			_, _ = fmt.Fprintf(tw, "\texamples\t%s\n",
+				strings.Join(u.Examples, ", "))
+		}
+	}
+
+	_, _ = fmt.Fprintf(tw,
+		"\n%d deprecations used in %d of %d checked documents\n",
+		len(report.Deprecations), report.Affected, report.Checked)
+
+	err := tw.Flush()
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,537 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/ttab/revisor"
+	"github.com/urfave/cli/v2"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+func docsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "docs",
+		Usage: "generates documentation for constraint sets",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format, \"markdown\" or \"html\"",
+				Value: "markdown",
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "Title of the documentation",
+				Value: "Document specifications",
+			},
+			&cli.PathFlag{
+				Name:  "out",
+				Usage: "Write the documentation to a file instead of stdout",
+			},
+		}, specFlags...),
+		Action: docsAction,
+	}
+}
+
+func docsAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	page := newDocsPage(c.String("title"), validator)
+
+	var render func(out io.Writer, page docsPage) error
+
+	switch c.String("format") {
+	case "markdown", "md":
+		render = renderMarkdownDocs
+	case "html":
+		render = renderHTMLDocs
+	default:
+		return fmt.Errorf("unknown documentation format %q", c.String("format"))
+	}
+
+	return writeOutput(c.Path("out"), func(w io.Writer) error {
+		err := render(w, page)
+		if err != nil {
+			return fmt.Errorf("render documentation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// writeOutput calls fn with a writer for the output file, or stdout if no
+// file was specified.
+func writeOutput(path string, fn func(w io.Writer) error) error {
+	if path == "" {
+		return fn(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+
+	err = fn(f)
+	if err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("close output file: %w", err)
+	}
+
+	return nil
+}
+
+type docsPage struct {
+	Title     string
+	Documents []docsDocument
+	Enums     []docsEnum
+	Policies  []docsPolicy
+}
+
+type docsDocument struct {
+	Anchor      string
+	Type        string
+	Name        string
+	Description string
+	Deprecated  *revisor.Deprecation
+	Attributes  []docsValue
+	Blocks      []docsBlock
+}
+
+type docsBlock struct {
+	Anchor      string
+	Path        string
+	Kind        string
+	Signature   string
+	Name        string
+	Description string
+	Deprecated  *revisor.Deprecation
+	Count       string
+	Conditions  string
+	Attributes  []docsValue
+	Data        []docsValue
+}
+
+type docsValue struct {
+	Name        string
+	Required    bool
+	Description string
+	Rules       []string
+	EnumRef     string
+	EnumAnchor  string
+	HTMLPolicy  string
+	HTMLAnchor  string
+	Deprecated  *revisor.Deprecation
+	Labels      []string
+	Hints       []string
+}
+
+type docsEnum struct {
+	Anchor      string
+	ID          string
+	Name        string
+	Description string
+	Values      []revisor.EnumValueSpec
+}
+
+type docsPolicy struct {
+	Anchor      string
+	Name        string
+	Description string
+	Elements    []docsElement
+}
+
+type docsElement struct {
+	Name       string
+	Attributes []docsValue
+}
+
+func newDocsPage(title string, v *revisor.Validator) docsPage {
+	page := docsPage{
+		Title: title,
+	}
+
+	formats := v.Formats()
+
+	for _, spec := range v.DocumentSpecs() {
+		doc := docsDocument{
+			Anchor:      anchor("doc", spec.Type),
+			Type:        spec.Type,
+			Name:        spec.Name,
+			Description: spec.Description,
+			Deprecated:  spec.Deprecated,
+			Attributes:  docsValues(formats, spec.Attributes),
+		}
+
+		for _, kind := range []revisor.BlockKind{
+			revisor.BlockKindLink,
+			revisor.BlockKindMeta,
+			revisor.BlockKindContent,
+		} {
+			doc.Blocks = appendDocsBlocks(formats, doc.Blocks,
+				spec.Type, nil, spec.Blocks(kind))
+		}
+
+		page.Documents = append(page.Documents, doc)
+	}
+
+	for _, e := range v.Enums() {
+		page.Enums = append(page.Enums, docsEnum{
+			Anchor:      anchor("enum", e.ID),
+			ID:          e.ID,
+			Name:        e.Name,
+			Description: e.Description,
+			Values:      e.Values,
+		})
+	}
+
+	for _, p := range v.HTMLPolicies() {
+		policy := docsPolicy{
+			Anchor:      anchor("policy", p.Name),
+			Name:        p.Name,
+			Description: p.Description,
+		}
+
+		for _, name := range sortedKeys(p.Elements) {
+			policy.Elements = append(policy.Elements, docsElement{
+				Name:       name,
+				Attributes: docsValues(formats, p.Elements[name].Attributes),
+			})
+		}
+
+		page.Policies = append(page.Policies, policy)
+	}
+
+	return page
+}
+
+func appendDocsBlocks(
+	formats *revisor.FormatRegistry,
+	list []docsBlock, docType string, path []string, specs []*revisor.BlockSpec,
+) []docsBlock {
+	for _, spec := range specs {
+		sig := describeSignature(spec.Declares)
+		blockPath := append(path[0:len(path):len(path)],
+			fmt.Sprintf("%s %s", spec.Kind, sig))
+
+		b := docsBlock{
+			Anchor: anchor("block", docType+" "+
+				strings.Join(blockPath, " ")),
+			Path:        strings.Join(blockPath, " › "),
+			Kind:        string(spec.Kind),
+			Signature:   sig,
+			Name:        spec.Name,
+			Description: spec.Description,
+			Deprecated:  spec.Deprecated,
+			Count:       describeCount(spec),
+			Attributes:  docsValues(formats, spec.Attributes),
+			Data:        docsValues(formats, spec.Data),
+		}
+
+		list = append(list, b)
+
+		for i, cond := range spec.Conditional {
+			list = append(list, docsBlock{
+				Anchor: anchor("block", fmt.Sprintf("%s %s when %d",
+					docType, strings.Join(blockPath, " "), i+1)),
+				Path:        b.Path,
+				Kind:        b.Kind,
+				Signature:   sig,
+				Name:        cond.Name,
+				Description: cond.Description,
+				Deprecated:  cond.Deprecated,
+				Count:       describeCount(cond),
+				Conditions:  cond.Match.RequirementsWithFormats(formats),
+				Attributes:  docsValues(formats, cond.Attributes),
+				Data:        docsValues(formats, cond.Data),
+			})
+
+			for _, kind := range []revisor.BlockKind{
+				revisor.BlockKindLink,
+				revisor.BlockKindMeta,
+				revisor.BlockKindContent,
+			} {
+				list = appendDocsBlocks(formats, list, docType,
+					blockPath, cond.Blocks(kind))
+			}
+		}
+
+		for _, kind := range []revisor.BlockKind{
+			revisor.BlockKindLink,
+			revisor.BlockKindMeta,
+			revisor.BlockKindContent,
+		} {
+			list = appendDocsBlocks(formats, list, docType,
+				blockPath, spec.Blocks(kind))
+		}
+	}
+
+	return list
+}
+
+// docsValues describes the constraints in the constraint map.
+func docsValues(
+	formats *revisor.FormatRegistry, cm revisor.ConstraintMap,
+) []docsValue {
+	values := make([]docsValue, 0, len(cm.Keys))
+
+	for _, k := range cm.Keys {
+		c := cm.Constraints[k]
+
+		v := docsValue{
+			Name:        k,
+			Required:    !c.Optional && !c.AllowEmpty,
+			Description: c.Description,
+			Rules:       describeRules(formats, c),
+			EnumRef:     c.EnumRef,
+			Deprecated:  c.Deprecated,
+			Labels:      c.Labels,
+		}
+
+		if c.Name != "" && c.Description == "" {
+			v.Description = c.Name
+		}
+
+		if c.EnumRef != "" {
+			v.EnumAnchor = anchor("enum", c.EnumRef)
+		}
+
+		if c.Format == revisor.StringFormatHTML {
+			v.HTMLPolicy = c.HTMLPolicy
+			if v.HTMLPolicy == "" {
+				v.HTMLPolicy = "default"
+			}
+
+			v.HTMLAnchor = anchor("policy", v.HTMLPolicy)
+		}
+
+		for _, h := range sortedKeys(c.Hints) {
+			v.Hints = append(v.Hints, fmt.Sprintf("%s: %s",
+				h, strings.Join(c.Hints[h], ", ")))
+		}
+
+		values = append(values, v)
+	}
+
+	return values
+}
+
+func describeRules(
+	formats *revisor.FormatRegistry, c revisor.StringConstraint,
+) []string {
+	var rules []string
+
+	if c.Const != nil {
+		rules = append(rules, fmt.Sprintf("must be %q", *c.Const))
+	}
+
+	if len(c.Enum) > 0 {
+		rules = append(rules, "one of: "+quoteJoin(c.Enum))
+	}
+
+	if c.Pattern != nil {
+		rules = append(rules, fmt.Sprintf("matches the regexp `%s`",
+			c.Pattern.String()))
+	}
+
+	if len(c.Glob) > 0 {
+		rules = append(rules, c.Glob.String())
+	}
+
+	if c.Time != "" {
+		rules = append(rules, fmt.Sprintf("a timestamp in the format %q", c.Time))
+	}
+
+	if c.Format != revisor.StringFormatNone && c.Format != revisor.StringFormatHTML {
+		rules = append(rules, formats.Describe(c.Format))
+	}
+
+	if c.Geometry != "" {
+		rules = append(rules, fmt.Sprintf("geometry %q", c.Geometry))
+	}
+
+	if c.WGS84 {
+		rules = append(rules, "WGS84 coordinates")
+	}
+
+	if c.ValidRings {
+		rules = append(rules, "valid polygon rings")
+	}
+
+	if c.MinPoints > 0 {
+		rules = append(rules, fmt.Sprintf("at least %d points", c.MinPoints))
+	}
+
+	if c.BoundingBox != nil {
+		rules = append(rules, "within "+c.BoundingBox.String())
+	}
+
+	if len(c.ColourFormats) > 0 {
+		formats := make([]string, len(c.ColourFormats))
+
+		for i, f := range c.ColourFormats {
+			formats[i] = string(f)
+		}
+
+		rules = append(rules, "colour formats: "+quoteJoin(formats))
+	}
+
+	if c.NormaliseColour != "" {
+		rules = append(rules, fmt.Sprintf("normalised to %q", c.NormaliseColour))
+	}
+
+	if len(c.Languages) > 0 {
+		rules = append(rules, "languages: "+quoteJoin(c.Languages))
+	}
+
+	if c.RequireRegion {
+		rules = append(rules, "region required")
+	}
+
+	if c.Canonical {
+		rules = append(rules, "canonical form")
+	}
+
+	if len(c.Schemes) > 0 {
+		rules = append(rules, "schemes: "+quoteJoin(c.Schemes))
+	}
+
+	if len(c.Hosts) > 0 {
+		rules = append(rules, "host "+c.Hosts.String())
+	}
+
+	if c.AllowQuery {
+		rules = append(rules, "query allowed")
+	}
+
+	if c.AllowFragment {
+		rules = append(rules, "fragment allowed")
+	}
+
+	if c.MinDuration != nil {
+		rules = append(rules, "at least "+c.MinDuration.String())
+	}
+
+	if c.MaxDuration != nil {
+		rules = append(rules, "at most "+c.MaxDuration.String())
+	}
+
+	if len(c.MIMETypes) > 0 {
+		rules = append(rules, "MIME type "+c.MIMETypes.String())
+	}
+
+	if c.JSONSchema != nil {
+		rules = append(rules, "follows the JSON schema")
+	}
+
+	if c.AllowEmpty {
+		rules = append(rules, "may be empty")
+	}
+
+	return rules
+}
+
+func describeCount(spec *revisor.BlockSpec) string {
+	switch {
+	case spec.Count != nil:
+		return fmt.Sprintf("exactly %d", *spec.Count)
+	case spec.MinCount != nil && spec.MaxCount != nil:
+		return fmt.Sprintf("%d to %d", *spec.MinCount, *spec.MaxCount)
+	case spec.MinCount != nil:
+		return fmt.Sprintf("at least %d", *spec.MinCount)
+	case spec.MaxCount != nil:
+		return fmt.Sprintf("at most %d", *spec.MaxCount)
+	}
+
+	return "any number"
+}
+
+func describeSignature(sig revisor.BlockSignature) string {
+	var parts []string
+
+	if sig.Rel != "" {
+		parts = append(parts, "rel="+sig.Rel)
+	}
+
+	if sig.Type != "" {
+		parts = append(parts, "type="+sig.Type)
+	}
+
+	if sig.Role != "" {
+		parts = append(parts, "role="+sig.Role)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func quoteJoin(values []string) string {
+	q := make([]string, len(values))
+
+	for i, v := range values {
+		q[i] = fmt.Sprintf("%q", v)
+	}
+
+	return strings.Join(q, ", ")
+}
+
+var nonAnchorChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func anchor(prefix string, name string) string {
+	slug := nonAnchorChars.ReplaceAllString(strings.ToLower(name), "-")
+
+	return prefix + "-" + strings.Trim(slug, "-")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+var markdownCellEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func renderMarkdownDocs(out io.Writer, page docsPage) error {
+	tpl, err := texttemplate.New("docs.md.tmpl").Funcs(texttemplate.FuncMap{
+		"cell": markdownCellEscaper.Replace,
+		"join": strings.Join,
+	}).ParseFS(templateFS, "templates/docs.md.tmpl")
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	return tpl.Execute(out, page) //nolint:wrapcheck
+}
+
+func renderHTMLDocs(out io.Writer, page docsPage) error {
+	tpl, err := htmltemplate.New("docs.html.tmpl").Funcs(htmltemplate.FuncMap{
+		"join": strings.Join,
+	}).ParseFS(templateFS, "templates/docs.html.tmpl")
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	return tpl.Execute(out, page) //nolint:wrapcheck
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+)
+
+func mustf(t *testing.T, err error, format string, a ...any) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf(format+": %v", append(a, err)...)
+	}
+}
+
+func TestDocs(t *testing.T) {
+	regenerate := os.Getenv("REGENERATE") == "true"
+
+	renderers := map[string]func(out io.Writer, page docsPage) error{
+		".md":   renderMarkdownDocs,
+		".html": renderHTMLDocs,
+	}
+
+	for _, name := range []string{"transcript", "migrations"} {
+		var set revisor.ConstraintSet
+
+		err := internal.UnmarshalFile(filepath.Join(
+			"..", "..", "testdata", "constraints", name+".json"), &set)
+		mustf(t, err, "load %s constraints", name)
+
+		validator, err := revisor.NewValidator(set)
+		mustf(t, err, "create %s validator", name)
+
+		page := newDocsPage("Test specifications", validator)
+
+		for ext, render := range renderers {
+			t.Run(name+ext, func(t *testing.T) {
+				var buf bytes.Buffer
+
+				err := render(&buf, page)
+				mustf(t, err, "render documentation")
+
+				goldenPath := filepath.Join("testdata", "docs-"+name+ext)
+
+				if regenerate {
+					err := os.WriteFile(goldenPath, buf.Bytes(), 0o600)
+					mustf(t, err, "write golden file")
+				}
+
+				want, err := os.ReadFile(goldenPath)
+				mustf(t, err, "read golden file")
+
+				if diff := cmp.Diff(string(want), buf.String()); diff != "" {
+					t.Fatalf("documentation mismatch (-want +got):\n%s", diff)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/invopop/jsonschema"
+	"github.com/urfave/cli/v2"
+)
+
+func documentSchemaCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "document-schema",
+		Usage: "generates a JSON schema for documents from constraint sets",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "Document type to generate a schema for, defaults to all declared types",
+			},
+		}, specFlags...),
+		Action: documentSchemaAction,
+	}
+}
+
+func documentSchemaAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	var schema *jsonschema.Schema
+
+	if docType := c.String("type"); docType != "" {
+		schema, err = validator.DocumentJSONSchema(docType)
+		if err != nil {
+			return fmt.Errorf("generate schema: %w", err)
+		}
+	} else {
+		schema = validator.DocumentsJSONSchema()
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+
+	enc.SetIndent("", "  ")
+
+	err = enc.Encode(schema)
+	if err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+
+	return nil
+}
@@ -32,6 +32,15 @@ func main() {
 					return nil
 				},
 			},
+			documentSchemaCommand(),
+			coverageCommand(),
+			deprecationsCommand(),
+			docsCommand(),
+			codegenCommand(),
+			serveCommand(),
+			convertCommand(),
+			migrateCommand(),
+			migrateDocumentsCommand(),
 		},
 	}
 
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ttab/revisor"
+	"github.com/urfave/cli/v2"
+)
+
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "upgrades constraint set files to the current format version",
+		ArgsUsage: "<constraint set file>...",
+		Description: `Applies the format migrations that are needed to upgrade the constraint
+sets to the current format version. The migrated constraint set is written to
+stdout, or back to the files when --write is used. The applied migrations are
+reported on stderr.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "write",
+				Aliases: []string{"w"},
+				Usage:   "Write the migrated constraint sets back to the files",
+			},
+		},
+		Action: migrateAction,
+	}
+}
+
+func migrateAction(c *cli.Context) error {
+	paths := c.Args().Slice()
+	write := c.Bool("write")
+
+	if len(paths) == 0 {
+		return errors.New("no constraint set files given")
+	}
+
+	if !write && len(paths) > 1 {
+		return errors.New("use --write to migrate more than one file")
+	}
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("read constraint set: %w", err)
+		}
+
+		out, res, err := revisor.MigrateConstraintSetFormat(p, data)
+		if err != nil {
+			return fmt.Errorf("migrate constraint set: %w", err)
+		}
+
+		reportMigration(c.App.ErrWriter, p, res)
+
+		switch {
+		case !write:
+			_, err = os.Stdout.Write(out)
+			if err != nil {
+				return fmt.Errorf("write output: %w", err)
+			}
+		case res.Changed():
+			err = os.WriteFile(p, out, 0o600)
+			if err != nil {
+				return fmt.Errorf("write migrated constraint set: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func reportMigration(w io.Writer, name string, res revisor.FormatMigrationResult) {
+	if w == nil {
+		w = os.Stderr
+	}
+
+	if !res.Changed() {
+		_, _ = fmt.Fprintf(w, "%s: already at format version %d\n",
+			name, res.ToVersion)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s: migrated from format version %d to %d\n",
+		name, res.FromVersion, res.ToVersion)
+
+	for _, desc := range res.Applied {
+		_, _ = fmt.Fprintf(w, "  - %s\n", desc)
+	}
+}
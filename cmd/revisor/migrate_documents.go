@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+	"github.com/urfave/cli/v2"
+)
+
+func migrateDocumentsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "migrate-documents",
+		Usage:     "applies the document migrations declared in the constraint sets",
+		ArgsUsage: "[document files or glob patterns...]",
+		Description: `Rewrites documents using the migrations declared in the constraint sets.
+The migrated document is written to stdout, or back to the files when --write
+is used. The changes that were made are reported on stderr.`,
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:    "write",
+				Aliases: []string{"w"},
+				Usage:   "Write the migrated documents back to the files",
+			},
+			&cli.StringSliceFlag{
+				Name:  "migration",
+				Usage: "Only apply the migration with the given ID, can be repeated",
+			},
+		}, specFlags...),
+		Action: migrateDocumentsAction,
+	}
+}
+
+func migrateDocumentsAction(c *cli.Context) error {
+	validator, err := validatorFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	paths, err := expandGlobs(c.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	write := c.Bool("write")
+
+	switch {
+	case len(paths) == 0:
+		return errors.New("no documents to migrate")
+	case !write && len(paths) > 1:
+		return errors.New("use --write to migrate more than one document")
+	}
+
+	errOut := c.App.ErrWriter
+	if errOut == nil {
+		errOut = os.Stderr
+	}
+
+	for _, p := range paths {
+		var doc newsdoc.Document
+
+		err := internal.UnmarshalFile(p, &doc)
+		if err != nil {
+			return fmt.Errorf("load document %q: %w", p, err)
+		}
+
+		changes, err := validator.Migrate(&doc, c.StringSlice("migration")...)
+		if err != nil {
+			return fmt.Errorf("migrate document %q: %w", p, err)
+		}
+
+		reportDocumentMigration(errOut, p, changes)
+
+		if write && len(changes) == 0 {
+			continue
+		}
+
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal document %q: %w", p, err)
+		}
+
+		data = append(data, '\n')
+
+		if !write {
+			_, err = os.Stdout.Write(data)
+			if err != nil {
+				return fmt.Errorf("write output: %w", err)
+			}
+
+			continue
+		}
+
+		err = os.WriteFile(p, data, 0o600)
+		if err != nil {
+			return fmt.Errorf("write migrated document: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func reportDocumentMigration(
+	w io.Writer, name string, changes []revisor.MigrationChange,
+) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: no changes\n", name)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s:\n", name)
+
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(w, "  - %s\n", change.String())
+	}
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/urfave/cli/v2"
+)
+
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "runs a HTTP validation service",
+		Description: `Loads all the constraint sets (*.json, *.yaml and *.yml) in the spec
+directory and serves:
+
+   POST /validate  validates the posted document
+   POST /prune     prunes the posted document and returns it
+   GET  /schema    returns the JSON schema for documents, use the "type"
+                   query parameter to get the schema for a single type
+
+Variants are selected per request with the "variant" query parameter. Send
+SIGHUP to reload the constraint sets, or use --watch-interval to check for
+changes periodically.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Value: ":8080",
+				Usage: "The address to listen to",
+			},
+			&cli.PathFlag{
+				Name:     "dir",
+				Usage:    "Directory to load constraint sets from",
+				Required: true,
+			},
+			&cli.Int64Flag{
+				Name:  "max-body-size",
+				Value: 4 << 20,
+				Usage: "Maximum size of request bodies in bytes",
+			},
+			&cli.DurationFlag{
+				Name:  "watch-interval",
+				Usage: "Check the constraint sets for changes at this interval",
+			},
+			&cli.DurationFlag{
+				Name:  "shutdown-timeout",
+				Value: 10 * time.Second,
+				Usage: "How long to wait for requests to finish when shutting down",
+			},
+		},
+		Action: serveAction,
+	}
+}
+
+func serveAction(c *cli.Context) error {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	handleOpts := []revisor.ValidatorHandleOption{
+		revisor.WithReloadErrorHandler(func(err error) {
+			logger.Error("failed to reload constraint sets, keeping the current ones",
+				"err", err)
+		}),
+		revisor.WithReloadHandler(func(_ *revisor.Validator) {
+			logger.Info("loaded constraint sets")
+		}),
+	}
+
+	if d := c.Duration("watch-interval"); d > 0 {
+		handleOpts = append(handleOpts, revisor.WithReloadInterval(d))
+	}
+
+	handle, err := revisor.NewValidatorHandleFromDir(c.Path("dir"), handleOpts...)
+	if err != nil {
+		return fmt.Errorf("load constraint sets: %w", err)
+	}
+
+	srv := validationServer{
+		handle:      handle,
+		maxBodySize: c.Int64("max-body-size"),
+		logger:      logger,
+	}
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reload := make(chan os.Signal, 1)
+
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-reload:
+				_, _ = handle.Reload()
+			}
+		}
+	}()
+
+	if c.Duration("watch-interval") > 0 {
+		go func() {
+			_ = handle.Run(ctx)
+		}()
+	}
+
+	server := http.Server{
+		Addr:              c.String("addr"),
+		Handler:           srv.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		// Requests must outlive the signal context so that
+		// Shutdown can let them finish.
+		BaseContext: func(_ net.Listener) context.Context {
+			return context.WithoutCancel(ctx)
+		},
+	}
+
+	serveErr := make(chan error, 1)
+
+	go func() {
+		logger.Info("starting validation service", "addr", server.Addr)
+
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("listen and serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(
+		context.Background(), c.Duration("shutdown-timeout"))
+	defer cancel()
+
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		return fmt.Errorf("graceful shutdown: %w", err)
+	}
+
+	return nil
+}
+
+type validationServer struct {
+	handle      *revisor.ValidatorHandle
+	maxBodySize int64
+	logger      *slog.Logger
+}
+
+func (s *validationServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /validate", s.handleValidate)
+	mux.HandleFunc("POST /prune", s.handlePrune)
+	mux.HandleFunc("GET /schema", s.handleSchema)
+
+	return mux
+}
+
+type validateResponse struct {
+	Valid   bool                       `json:"valid"`
+	Results []revisor.ValidationResult `json:"results"`
+}
+
+type pruneResponse struct {
+	Document *newsdoc.Document          `json:"document"`
+	Results  []revisor.ValidationResult `json:"results"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *validationServer) handleValidate(w http.ResponseWriter, req *http.Request) {
+	v := s.requestValidator(req)
+
+	doc, ok := s.readDocument(w, req)
+	if !ok {
+		return
+	}
+
+	res, err := v.ValidateDocument(req.Context(), doc)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError,
+			fmt.Errorf("validate document: %w", err))
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, validateResponse{
+		Valid:   len(res) == 0,
+		Results: nonNil(res),
+	})
+}
+
+func (s *validationServer) handlePrune(w http.ResponseWriter, req *http.Request) {
+	v := s.requestValidator(req)
+
+	doc, ok := s.readDocument(w, req)
+	if !ok {
+		return
+	}
+
+	res, err := v.Prune(req.Context(), doc)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError,
+			fmt.Errorf("prune document: %w", err))
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, pruneResponse{
+		Document: doc,
+		Results:  nonNil(res),
+	})
+}
+
+func (s *validationServer) handleSchema(w http.ResponseWriter, req *http.Request) {
+	v := s.requestValidator(req)
+
+	var schema *jsonschema.Schema
+
+	if docType := req.URL.Query().Get("type"); docType != "" {
+		ds, err := v.DocumentJSONSchema(docType)
+		if err != nil {
+			s.writeError(w, http.StatusNotFound, err)
+
+			return
+		}
+
+		schema = ds
+	} else {
+		schema = v.DocumentsJSONSchema()
+	}
+
+	s.writeJSON(w, http.StatusOK, schema)
+}
+
+// requestValidator returns the current validator with the variants that were
+// requested through the "variant" query parameter.
+func (s *validationServer) requestValidator(req *http.Request) *revisor.Validator {
+	v := s.handle.Validator()
+
+	var variants []revisor.Variant
+
+	for _, name := range req.URL.Query()["variant"] {
+		if name == "" {
+			continue
+		}
+
+		variants = append(variants, revisor.Variant{Name: name})
+	}
+
+	if len(variants) > 0 {
+		v = v.WithVariants(variants...)
+	}
+
+	return v
+}
+
+func (s *validationServer) readDocument(
+	w http.ResponseWriter, req *http.Request,
+) (*newsdoc.Document, bool) {
+	body := http.MaxBytesReader(w, req.Body, s.maxBodySize)
+
+	dec := json.NewDecoder(body)
+
+	dec.DisallowUnknownFields()
+
+	var doc newsdoc.Document
+
+	err := dec.Decode(&doc)
+	if err != nil {
+		status := http.StatusBadRequest
+
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		s.writeError(w, status, fmt.Errorf("invalid document: %w", err))
+
+		return nil, false
+	}
+
+	return &doc, true
+}
+
+func (s *validationServer) writeError(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError {
+		s.logger.Error("request failed", "err", err)
+	}
+
+	s.writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func (s *validationServer) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		s.logger.Error("failed to write response", "err", err)
+	}
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+
+	return s
+}
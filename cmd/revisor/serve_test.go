@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+)
+
+const serveTestSpec = `{
+  "version": 1,
+  "name": "serve",
+  "documents": [
+    {
+      "declares": "test/article",
+      "meta": [
+        {"declares": {"type": "test/note"}}
+      ]
+    }
+  ]
+}`
+
+const serveTestUUID = "4b7cb2d9-0af0-4bc0-bd54-5ef7e1a8bb2e"
+
+func testServer(t *testing.T, maxBodySize int64) *httptest.Server {
+	t.Helper()
+
+	handle, err := revisor.NewValidatorHandle(fstest.MapFS{
+		"serve.json": {Data: []byte(serveTestSpec)},
+	})
+	mustf(t, err, "create validator handle")
+
+	srv := validationServer{
+		handle:      handle,
+		maxBodySize: maxBodySize,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	server := httptest.NewServer(srv.handler())
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func testDocument(t *testing.T, docType string, meta ...newsdoc.Block) string {
+	t.Helper()
+
+	data, err := json.Marshal(newsdoc.Document{
+		UUID: serveTestUUID,
+		Type: docType,
+		Meta: meta,
+	})
+	mustf(t, err, "marshal document")
+
+	return string(data)
+}
+
+// request performs a request against the server and decodes the JSON
+// response into v if it's non-nil. Returns the response status code.
+func request(
+	t *testing.T, server *httptest.Server,
+	method string, path string, body string, v any,
+) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	mustf(t, err, "create request")
+
+	res, err := server.Client().Do(req)
+	mustf(t, err, "perform request")
+
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if v != nil {
+		err := json.NewDecoder(res.Body).Decode(v)
+		mustf(t, err, "decode %s %s response", method, path)
+	}
+
+	return res.StatusCode
+}
+
+func TestServeValidate(t *testing.T) {
+	server := testServer(t, 4<<10)
+
+	cases := map[string]struct {
+		Path     string
+		Document string
+		Valid    bool
+	}{
+		"valid": {
+			Path:     "/validate",
+			Document: testDocument(t, "test/article"),
+			Valid:    true,
+		},
+		"undeclared block": {
+			Path: "/validate",
+			Document: testDocument(t, "test/article",
+				newsdoc.Block{Type: "test/unknown"}),
+		},
+		"variant without parameter": {
+			Path:     "/validate",
+			Document: testDocument(t, "test/article#template"),
+		},
+		"variant": {
+			Path:     "/validate?variant=template",
+			Document: testDocument(t, "test/article#template"),
+			Valid:    true,
+		},
+		"other variant": {
+			Path:     "/validate?variant=draft",
+			Document: testDocument(t, "test/article#template"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var res validateResponse
+
+			status := request(t, server, http.MethodPost, tc.Path, tc.Document, &res)
+			if status != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", status)
+			}
+
+			if res.Valid != tc.Valid || res.Valid != (len(res.Results) == 0) {
+				t.Fatalf("expected valid to be %v, got: %#v", tc.Valid, res)
+			}
+		})
+	}
+}
+
+func TestServePrune(t *testing.T) {
+	server := testServer(t, 4<<10)
+
+	var res pruneResponse
+
+	status := request(t, server, http.MethodPost, "/prune",
+		testDocument(t, "test/article",
+			newsdoc.Block{Type: "test/note"},
+			newsdoc.Block{Type: "test/unknown"},
+		), &res)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+
+	if res.Document == nil || len(res.Document.Meta) != 1 ||
+		res.Document.Meta[0].Type != "test/note" {
+		t.Fatalf("expected the undeclared block to be pruned, got: %#v",
+			res.Document)
+	}
+
+	if res.Results == nil {
+		t.Fatal("expected results to be a list")
+	}
+}
+
+func TestServeSchema(t *testing.T) {
+	server := testServer(t, 4<<10)
+
+	var all map[string]any
+
+	status := request(t, server, http.MethodGet, "/schema", "", &all)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 for all types, got %d", status)
+	}
+
+	var single map[string]any
+
+	status = request(t, server, http.MethodGet, "/schema?type=test/article", "", &single)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 for a declared type, got %d", status)
+	}
+
+	var unknown errorResponse
+
+	status = request(t, server, http.MethodGet, "/schema?type=test/nope", "", &unknown)
+	if status != http.StatusNotFound || unknown.Error == "" {
+		t.Fatalf("expected a 404 error for an unknown type, got %d: %#v",
+			status, unknown)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	server := testServer(t, 256)
+
+	valid := testDocument(t, "test/article")
+
+	cases := map[string]struct {
+		Method string
+		Path   string
+		Body   string
+		Status int
+	}{
+		"validate with GET": {
+			Method: http.MethodGet,
+			Path:   "/validate",
+			Status: http.StatusMethodNotAllowed,
+		},
+		"prune with GET": {
+			Method: http.MethodGet,
+			Path:   "/prune",
+			Status: http.StatusMethodNotAllowed,
+		},
+		"schema with POST": {
+			Method: http.MethodPost,
+			Path:   "/schema",
+			Body:   valid,
+			Status: http.StatusMethodNotAllowed,
+		},
+		"unknown path": {
+			Method: http.MethodGet,
+			Path:   "/nope",
+			Status: http.StatusNotFound,
+		},
+		"empty body": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Status: http.StatusBadRequest,
+		},
+		"invalid JSON": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"uuid": `,
+			Status: http.StatusBadRequest,
+		},
+		"unknown field": {
+			Method: http.MethodPost,
+			Path:   "/prune",
+			Body:   `{"uuid": "` + serveTestUUID + `", "nope": true}`,
+			Status: http.StatusBadRequest,
+		},
+		"too large": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body: `{"uuid": "` + serveTestUUID + `", "title": "` +
+				strings.Repeat("a", 512) + `"}`,
+			Status: http.StatusRequestEntityTooLarge,
+		},
+		"too large prune": {
+			Method: http.MethodPost,
+			Path:   "/prune",
+			Body: `{"uuid": "` + serveTestUUID + `", "title": "` +
+				strings.Repeat("a", 512) + `"}`,
+			Status: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := request(t, server, tc.Method, tc.Path, tc.Body, nil)
+			if status != tc.Status {
+				t.Fatalf("expected status %d, got %d", tc.Status, status)
+			}
+		})
+	}
+
+	var res errorResponse
+
+	status := request(t, server, http.MethodPost, "/validate", `{"uuid": `, &res)
+	if status != http.StatusBadRequest || !strings.HasPrefix(res.Error, "invalid document") {
+		t.Fatalf("expected a JSON error response, got %d: %#v", status, res)
+	}
+}
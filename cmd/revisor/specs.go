@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ttab/revisor"
+	"github.com/urfave/cli/v2"
+)
+
+var specFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:     "spec",
+		Usage:    "Constraint set file to load, can be repeated",
+		Required: true,
+	},
+	&cli.StringSliceFlag{
+		Name:  "variant",
+		Usage: "Document type variant to allow, f.ex. \"template\"",
+	},
+}
+
+func validatorFromFlags(c *cli.Context) (*revisor.Validator, error) {
+	paths := c.StringSlice("spec")
+
+	sets, err := revisor.LoadConstraintSetFiles(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("load constraint sets: %w", err)
+	}
+
+	sets, err = revisor.ResolveImports(importLoader(paths), sets...)
+	if err != nil {
+		return nil, fmt.Errorf("resolve imports: %w", err)
+	}
+
+	v, err := revisor.NewValidator(sets...)
+	if err != nil {
+		return nil, fmt.Errorf("create validator: %w", err)
+	}
+
+	var variants []revisor.Variant
+
+	for _, name := range c.StringSlice("variant") {
+		variants = append(variants, revisor.Variant{Name: name})
+	}
+
+	if len(variants) > 0 {
+		v = v.WithVariants(variants...)
+	}
+
+	return v, nil
+}
+
+// importLoader returns a loader that looks for imported constraint sets in the
+// directories of the spec files.
+func importLoader(paths []string) revisor.ConstraintSetLoader {
+	var dirs []string
+
+	for _, p := range paths {
+		dir := filepath.Dir(p)
+
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return revisor.ConstraintSetLoaderFunc(func(name string) (revisor.ConstraintSet, error) {
+		var errs []error
+
+		for _, dir := range dirs {
+			cs, err := revisor.FSConstraintSetLoader(os.DirFS(dir)).
+				LoadConstraintSet(name)
+			if err == nil {
+				return cs, nil
+			}
+
+			errs = append(errs, fmt.Errorf("%s: %w", dir, err))
+		}
+
+		return revisor.ConstraintSet{}, errors.Join(errs...)
+	})
+}
@@ -0,0 +1,167 @@
+// Package codegen generates types and helpers for the document types declared
+// in revisor constraint sets.
+package codegen
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ttab/revisor"
+)
+
+var blockKinds = []revisor.BlockKind{
+	revisor.BlockKindLink,
+	revisor.BlockKindMeta,
+	revisor.BlockKindContent,
+}
+
+// blockAttributes lists the block attributes in the order they are declared in
+// newsdoc.Block.
+var blockAttributes = []string{
+	"uuid", "uri", "url", "type", "title", "rel", "role",
+	"name", "value", "contenttype", "sensitivity",
+}
+
+var documentAttributes = []string{
+	"uri", "url", "title", "language",
+}
+
+// kindName returns the singular name used for the block kind in generated
+// identifiers.
+func kindName(kind revisor.BlockKind) string {
+	switch kind {
+	case revisor.BlockKindLink:
+		return "Link"
+	case revisor.BlockKindMeta:
+		return "Meta"
+	case revisor.BlockKindContent:
+		return "Content"
+	}
+
+	return string(kind)
+}
+
+// identifier creates an exported identifier from the parts, f.ex. "core/article"
+// becomes "CoreArticle".
+func identifier(parts ...string) string {
+	var b strings.Builder
+
+	for _, p := range parts {
+		upper := true
+
+		for _, r := range p {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				upper = true
+
+				continue
+			}
+
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+
+			b.WriteRune(r)
+		}
+	}
+
+	name := b.String()
+
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "T" + name
+	}
+
+	return name
+}
+
+// signatureParts returns the parts of the block signature that should be
+// used when naming the block.
+func signatureParts(sig revisor.BlockSignature) []string {
+	var parts []string
+
+	if sig.Rel != "" {
+		parts = append(parts, sig.Rel)
+	}
+
+	if sig.Type != "" {
+		parts = append(parts, sig.Type)
+	}
+
+	if sig.Role != "" {
+		parts = append(parts, sig.Role)
+	}
+
+	return parts
+}
+
+// nameSet hands out unique identifiers.
+type nameSet map[string]bool
+
+func (ns nameSet) unique(name string) string {
+	candidate := name
+
+	for i := 2; ns[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+
+	ns[candidate] = true
+
+	return candidate
+}
+
+// conditionalConstraints adds the keys of the conditional extensions to the
+// constraint map as optional values, as they only apply when the conditions
+// are met.
+func conditionalConstraints(
+	cm revisor.ConstraintMap,
+	conditional []*revisor.BlockSpec,
+	get func(bs *revisor.BlockSpec) revisor.ConstraintMap,
+) revisor.ConstraintMap {
+	res := cm.Copy()
+
+	for _, cond := range conditional {
+		extra := get(cond)
+
+		for _, k := range extra.Keys {
+			if _, exists := res.Constraints[k]; exists {
+				continue
+			}
+
+			c := extra.Constraints[k]
+
+			c.Optional = true
+			c.AllowEmpty = true
+
+			res.Keys = append(res.Keys, k)
+			res.Constraints[k] = c
+		}
+	}
+
+	slices.Sort(res.Keys)
+
+	return res
+}
+
+// conditionalBlocks returns the child blocks of the spec and the blocks
+// declared by its conditional extensions.
+func conditionalBlocks(
+	spec *revisor.BlockSpec, kind revisor.BlockKind,
+) []*revisor.BlockSpec {
+	blocks := slices.Clip(spec.Blocks(kind))
+
+	for _, cond := range spec.Conditional {
+		for _, b := range cond.Blocks(kind) {
+			if slices.ContainsFunc(blocks, func(e *revisor.BlockSpec) bool {
+				return e.Declares == b.Declares
+			}) {
+				continue
+			}
+
+			blocks = append(blocks, b)
+		}
+	}
+
+	return blocks
+}
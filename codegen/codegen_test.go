@@ -0,0 +1,105 @@
+package codegen_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+	"github.com/ttab/revisor/internal/revisorschemas"
+)
+
+func regenerateGoldenFiles() bool {
+	return os.Getenv("REGENERATE") == "true"
+}
+
+func mustf(t *testing.T, err error, format string, a ...any) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf(format+": %v", append(a, err)...)
+	}
+}
+
+func testValidators(t *testing.T) map[string]*revisor.Validator {
+	t.Helper()
+
+	sets, err := revisor.DecodeConstraintSetsFS(revisorschemas.Files(),
+		"core.json", "tt.json")
+	mustf(t, err, "decode constraint sets")
+
+	core, err := revisor.NewValidator(sets...)
+	mustf(t, err, "create core validator")
+
+	core = core.WithVariants(revisor.Variant{Name: "template"})
+
+	validators := map[string]*revisor.Validator{
+		"core": core,
+	}
+
+	for _, name := range []string{"geo", "transcript"} {
+		var set revisor.ConstraintSet
+
+		err := internal.UnmarshalFile(
+			filepath.Join("..", "testdata", "constraints", name+".json"), &set)
+		mustf(t, err, "load %s constraints", name)
+
+		v, err := revisor.NewValidator(set)
+		mustf(t, err, "create %s validator", name)
+
+		validators[name] = v
+	}
+
+	return validators
+}
+
+// testGolden generates code for the test constraint sets and compares it to
+// the golden files in testdata.
+func testGolden(
+	t *testing.T, ext string,
+	generate func(w io.Writer, v *revisor.Validator) error,
+) {
+	t.Helper()
+
+	regenerate := regenerateGoldenFiles()
+
+	validators := testValidators(t)
+
+	for _, name := range []string{"core", "geo", "transcript"} {
+		v := validators[name]
+
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := generate(&buf, v)
+			mustf(t, err, "generate code")
+
+			goldenPath := filepath.Join("testdata", name+ext)
+
+			if regenerate {
+				err := os.WriteFile(goldenPath, buf.Bytes(), 0o600)
+				mustf(t, err, "write golden file")
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			mustf(t, err, "read golden file")
+
+			if diff := cmp.Diff(string(want), buf.String()); diff != "" {
+				t.Fatalf("generated code mismatch (-want +got):\n%s", diff)
+			}
+
+			var again bytes.Buffer
+
+			err = generate(&again, v)
+			mustf(t, err, "generate code again")
+
+			if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+				t.Fatal("code generation is not deterministic")
+			}
+		})
+	}
+}
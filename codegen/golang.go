@@ -0,0 +1,705 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ttab/revisor"
+)
+
+// GoOptions controls the Go code generation.
+type GoOptions struct {
+	// Package is the name of the generated package, defaults to
+	// "documents".
+	Package string
+}
+
+// Go writes Go types and helpers for the document types declared in the
+// validator. Every declared block gets a struct with typed fields for its
+// attributes and data, parsed according to their declared format, together
+// with functions for parsing, building and finding the blocks. Enums are
+// declared as string types with constants for their values.
+func Go(w io.Writer, v *revisor.Validator, opts GoOptions) error {
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "documents"
+	}
+
+	g := goGenerator{
+		names: make(nameSet),
+		enums: make(map[string]string),
+	}
+
+	specs := v.DocumentSpecs()
+	enums := v.Enums()
+
+	for _, e := range enums {
+		g.enums[e.ID] = g.names.unique(identifier(e.ID))
+	}
+
+	g.line("// Code generated by revisor codegen go. DO NOT EDIT.")
+	g.line("")
+	g.line("package %s", pkg)
+	g.line("")
+	g.line("import (")
+	g.line("%q", "fmt")
+	g.line("%q", "strconv")
+	g.line("%q", "time")
+	g.line("")
+	g.line("%q", "github.com/ttab/newsdoc")
+	g.line(")")
+
+	if len(specs) > 0 {
+		g.line("")
+		g.line("// Declared document types.")
+		g.line("const (")
+
+		for _, spec := range specs {
+			g.line("DocumentType%s = %q", identifier(spec.Type), spec.Type)
+		}
+
+		g.line(")")
+	}
+
+	for _, e := range enums {
+		g.enumType(e)
+	}
+
+	for _, spec := range specs {
+		g.blocks(identifier(spec.Type), spec.Type+" documents", "doc *newsdoc.Document", "doc",
+			func(kind revisor.BlockKind) []*revisor.BlockSpec {
+				return spec.Blocks(kind)
+			})
+	}
+
+	g.out.WriteString(goHelpers)
+
+	src, err := format.Source(g.out.Bytes())
+	if err != nil {
+		return fmt.Errorf("format generated code: %w", err)
+	}
+
+	_, err = w.Write(src)
+	if err != nil {
+		return fmt.Errorf("write generated code: %w", err)
+	}
+
+	return nil
+}
+
+type goGenerator struct {
+	out   bytes.Buffer
+	names nameSet
+	enums map[string]string
+}
+
+func (g *goGenerator) line(format string, a ...any) {
+	if len(a) > 0 {
+		format = fmt.Sprintf(format, a...)
+	}
+
+	g.out.WriteString(format)
+	g.out.WriteString("\n")
+}
+
+// comment writes a comment, empty lines are dropped.
+func (g *goGenerator) comment(lines ...string) {
+	for _, l := range lines {
+		if l == "" {
+			continue
+		}
+
+		for _, part := range strings.Split(l, "\n") {
+			g.line("// %s", part)
+		}
+	}
+}
+
+func (g *goGenerator) enumType(e revisor.EnumSpec) {
+	name := g.enums[e.ID]
+
+	g.line("")
+	g.comment(fmt.Sprintf("%s is a value of the %q enum.", name, e.ID))
+
+	if e.Name != "" || e.Description != "" {
+		g.line("//")
+		g.comment(e.Name, e.Description)
+	}
+
+	g.line("type %s string", name)
+
+	var values []revisor.EnumValueSpec
+
+	for _, v := range e.Values {
+		if !v.Forbidden {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 0 {
+		return
+	}
+
+	g.line("")
+	g.line("const (")
+
+	for _, v := range values {
+		g.comment(v.Description)
+
+		if v.Deprecated != nil {
+			g.comment("Deprecated: " + v.Deprecated.Description())
+		}
+
+		g.line("%s %s = %q", g.names.unique(name+identifier(v.Value)),
+			name, v.Value)
+	}
+
+	g.line(")")
+}
+
+// blocks generates the types for the blocks of a document or block. The
+// source is the parameter of the find functions, and sourceName the name of
+// that parameter.
+func (g *goGenerator) blocks(
+	parent string, context string, source string, sourceName string,
+	blocks func(kind revisor.BlockKind) []*revisor.BlockSpec,
+) {
+	// Include the kind in the names if the same signature is used for
+	// different kinds of blocks.
+	seen := make(map[string]int)
+
+	for _, kind := range blockKinds {
+		for _, spec := range blocks(kind) {
+			seen[identifier(signatureParts(spec.Declares)...)]++
+		}
+	}
+
+	for _, kind := range blockKinds {
+		for _, spec := range blocks(kind) {
+			sig := identifier(signatureParts(spec.Declares)...)
+
+			name := parent + sig
+			if seen[sig] > 1 {
+				name = parent + kindName(kind) + sig
+			}
+
+			name = g.names.unique(name)
+
+			g.blockType(name, context, source, sourceName, kind, spec)
+		}
+	}
+}
+
+// goValue describes how a value is represented in Go.
+type goValue struct {
+	Type    string
+	Pointer bool
+	// Parse and Format are the names of the helpers used for parsing and
+	// formatting the value, empty for strings.
+	Parse  string
+	Format string
+}
+
+func (g *goGenerator) valueType(c revisor.StringConstraint, optional bool) goValue {
+	//nolint:exhaustive
+	switch c.Format {
+	case revisor.StringFormatInt:
+		return goValue{Type: "int", Pointer: optional, Parse: "parseInt", Format: "strconv.Itoa"}
+	case revisor.StringFormatFloat:
+		return goValue{Type: "float64", Pointer: optional, Parse: "parseFloat", Format: "formatFloat"}
+	case revisor.StringFormatBoolean:
+		return goValue{Type: "bool", Pointer: optional, Parse: "strconv.ParseBool", Format: "strconv.FormatBool"}
+	case revisor.StringFormatRFC3339:
+		return goValue{Type: "time.Time", Pointer: optional, Parse: "parseTime", Format: "formatTime"}
+	}
+
+	if name := g.enums[c.EnumRef]; name != "" {
+		return goValue{Type: name}
+	}
+
+	return goValue{Type: "string"}
+}
+
+// parseExpr returns an expression that parses the value expression, it
+// evaluates to the value and an error for formats that need parsing.
+func (v goValue) parseExpr(name string, value string) string {
+	switch {
+	case v.Parse == "" && v.Type == "string":
+		return value
+	case v.Parse == "":
+		return fmt.Sprintf("%s(%s)", v.Type, value)
+	case v.Pointer:
+		return fmt.Sprintf("parseOptional(%q, %s, %s)", name, value, v.Parse)
+	}
+
+	return fmt.Sprintf("parseValue(%q, %s, %s)", name, value, v.Parse)
+}
+
+// formatExpr returns an expression that formats the field as a string.
+func (v goValue) formatExpr(field string) string {
+	switch {
+	case v.Parse == "" && v.Type == "string":
+		return field
+	case v.Parse == "":
+		return fmt.Sprintf("string(%s)", field)
+	case v.Pointer:
+		return fmt.Sprintf("formatOptional(%s, %s)", field, v.Format)
+	}
+
+	return fmt.Sprintf("%s(%s)", v.Format, field)
+}
+
+func (v goValue) fieldType() string {
+	if v.Pointer {
+		return "*" + v.Type
+	}
+
+	return v.Type
+}
+
+type goField struct {
+	Name       string
+	Key        string
+	Data       bool
+	Optional   bool
+	Value      goValue
+	Constraint revisor.StringConstraint
+}
+
+// blockFieldNames are the names of the newsdoc.Block fields for the block
+// attributes.
+var blockFieldNames = map[string]string{
+	"uuid":        "UUID",
+	"uri":         "URI",
+	"url":         "URL",
+	"type":        "Type",
+	"title":       "Title",
+	"rel":         "Rel",
+	"role":        "Role",
+	"name":        "Name",
+	"value":       "Value",
+	"contenttype": "Contenttype",
+	"sensitivity": "Sensitivity",
+}
+
+func (g *goGenerator) blockFields(spec *revisor.BlockSpec) []goField {
+	var fields []goField
+
+	fieldNames := make(map[string]bool)
+
+	attributes := conditionalConstraints(spec.Attributes, spec.Conditional,
+		func(bs *revisor.BlockSpec) revisor.ConstraintMap {
+			return bs.Attributes
+		})
+
+	sig := map[string]string{
+		"type": spec.Declares.Type,
+		"rel":  spec.Declares.Rel,
+		"role": spec.Declares.Role,
+	}
+
+	for _, k := range blockAttributes {
+		if sig[k] != "" {
+			continue
+		}
+
+		c, declared := attributes.Constraints[k]
+		if !declared {
+			continue
+		}
+
+		name := blockFieldNames[k]
+		fieldNames[name] = true
+
+		fields = append(fields, goField{
+			Name:       name,
+			Key:        k,
+			Optional:   c.AllowEmpty,
+			Value:      g.valueType(c, c.AllowEmpty),
+			Constraint: c,
+		})
+	}
+
+	data := conditionalConstraints(spec.Data, spec.Conditional,
+		func(bs *revisor.BlockSpec) revisor.ConstraintMap {
+			return bs.Data
+		})
+
+	for _, k := range data.Keys {
+		c := data.Constraints[k]
+
+		name := identifier(k)
+		if fieldNames[name] {
+			name = "Data" + name
+		}
+
+		for i := 2; fieldNames[name]; i++ {
+			name = identifier(k) + strconv.Itoa(i)
+		}
+
+		fieldNames[name] = true
+
+		optional := c.Optional || c.AllowEmpty
+
+		fields = append(fields, goField{
+			Name:       name,
+			Key:        k,
+			Data:       true,
+			Optional:   c.Optional,
+			Value:      g.valueType(c, optional),
+			Constraint: c,
+		})
+	}
+
+	return fields
+}
+
+func describeBlock(kind revisor.BlockKind, sig revisor.BlockSignature) string {
+	var parts []string
+
+	if sig.Type != "" {
+		parts = append(parts, fmt.Sprintf("type %q", sig.Type))
+	}
+
+	if sig.Rel != "" {
+		parts = append(parts, fmt.Sprintf("rel %q", sig.Rel))
+	}
+
+	if sig.Role != "" {
+		parts = append(parts, fmt.Sprintf("role %q", sig.Role))
+	}
+
+	return fmt.Sprintf("%s with %s", kind.Description(1),
+		strings.Join(parts, " and "))
+}
+
+func (g *goGenerator) blockType(
+	name string, context string, source string, sourceName string,
+	kind revisor.BlockKind, spec *revisor.BlockSpec,
+) {
+	fields := g.blockFields(spec)
+	desc := describeBlock(kind, spec.Declares)
+	var list string
+
+	switch kind {
+	case revisor.BlockKindLink:
+		list = sourceName + ".Links"
+	case revisor.BlockKindMeta:
+		list = sourceName + ".Meta"
+	case revisor.BlockKindContent:
+		list = sourceName + ".Content"
+	}
+
+	g.line("")
+	g.comment(fmt.Sprintf("%s is a %s in %s.", name, desc, context))
+
+	if spec.Name != "" || spec.Description != "" {
+		g.line("//")
+		g.comment(spec.Name, spec.Description)
+	}
+
+	if spec.Deprecated != nil {
+		g.line("//")
+		g.comment("Deprecated: " + spec.Deprecated.Description())
+	}
+
+	g.line("type %s struct {", name)
+
+	for _, f := range fields {
+		g.comment(f.Constraint.Description)
+
+		if f.Constraint.Deprecated != nil {
+			g.comment("Deprecated: " + f.Constraint.Deprecated.Description())
+		}
+
+		g.line("%s %s", f.Name, f.Value.fieldType())
+	}
+
+	g.line("}")
+
+	// Parse function.
+	g.line("")
+	g.comment(fmt.Sprintf("Parse%s parses a %s.", name, desc))
+	g.line("func Parse%s(block newsdoc.Block) (%s, error) {", name, name)
+
+	needsErr := false
+
+	for _, f := range fields {
+		if f.Value.Parse != "" {
+			needsErr = true
+		}
+	}
+
+	if needsErr {
+		g.line("var (")
+		g.line("v %s", name)
+		g.line("err error")
+		g.line(")")
+	} else {
+		g.line("var v %s", name)
+	}
+
+	g.line("")
+
+	for _, f := range fields {
+		source := "block." + blockFieldNames[f.Key]
+		label := f.Key
+
+		if f.Data {
+			source = fmt.Sprintf("block.Data[%q]", f.Key)
+			label = "data " + f.Key
+		}
+
+		if f.Value.Parse == "" {
+			g.line("v.%s = %s", f.Name, f.Value.parseExpr(label, source))
+
+			continue
+		}
+
+		g.line("")
+		g.line("v.%s, err = %s", f.Name, f.Value.parseExpr(label, source))
+		g.line("if err != nil {")
+		g.line("return %s{}, err", name)
+		g.line("}")
+	}
+
+	g.line("")
+	g.line("return v, nil")
+	g.line("}")
+
+	// Builder.
+	g.line("")
+	g.comment(fmt.Sprintf("Block returns the %s as a newsdoc block.", desc))
+	g.line("func (v %s) Block() newsdoc.Block {", name)
+	g.line("b := newsdoc.Block{")
+
+	if spec.Declares.Type != "" {
+		g.line("Type: %q,", spec.Declares.Type)
+	}
+
+	if spec.Declares.Rel != "" {
+		g.line("Rel: %q,", spec.Declares.Rel)
+	}
+
+	if spec.Declares.Role != "" {
+		g.line("Role: %q,", spec.Declares.Role)
+	}
+
+	for _, f := range fields {
+		if f.Data {
+			continue
+		}
+
+		g.line("%s: %s,", blockFieldNames[f.Key], f.Value.formatExpr("v."+f.Name))
+	}
+
+	g.line("}")
+
+	var hasData bool
+
+	for _, f := range fields {
+		if f.Data {
+			hasData = true
+		}
+	}
+
+	if hasData {
+		g.line("")
+		g.line("data := make(newsdoc.DataMap)")
+
+		for _, f := range fields {
+			if !f.Data {
+				continue
+			}
+
+			value := f.Value.formatExpr("v." + f.Name)
+
+			if f.Optional {
+				g.line("setOptional(data, %q, %s)", f.Key, value)
+			} else {
+				g.line("data[%q] = %s", f.Key, value)
+			}
+		}
+
+		g.line("")
+		g.line("if len(data) > 0 {")
+		g.line("b.Data = data")
+		g.line("}")
+	}
+
+	g.line("")
+	g.line("return b")
+	g.line("}")
+
+	// Finders.
+	single := spec.Count != nil && *spec.Count <= 1 ||
+		spec.MaxCount != nil && *spec.MaxCount <= 1
+
+	g.line("")
+	g.comment(fmt.Sprintf("Get%s returns the first %s, false is returned if it doesn't exist.", name, desc))
+	g.line("func Get%s(%s) (%s, bool, error) {", name, source, name)
+	g.line("for _, b := range %s {", list)
+	g.line("if !blockMatches(b, %q, %q, %q) {", spec.Declares.Type, spec.Declares.Rel, spec.Declares.Role)
+	g.line("continue")
+	g.line("}")
+	g.line("")
+	g.line("v, err := Parse%s(b)", name)
+	g.line("if err != nil {")
+	g.line("return %s{}, true, fmt.Errorf(%q, err)", name, "parse "+desc+": %w")
+	g.line("}")
+	g.line("")
+	g.line("return v, true, nil")
+	g.line("}")
+	g.line("")
+	g.line("return %s{}, false, nil", name)
+	g.line("}")
+
+	if !single {
+		g.line("")
+		g.comment(fmt.Sprintf("All%s returns all the %ss.", name, desc))
+		g.line("func All%s(%s) ([]%s, error) {", name, source, name)
+		g.line("var res []%s", name)
+		g.line("")
+		g.line("for i, b := range %s {", list)
+		g.line("if !blockMatches(b, %q, %q, %q) {", spec.Declares.Type, spec.Declares.Rel, spec.Declares.Role)
+		g.line("continue")
+		g.line("}")
+		g.line("")
+		g.line("v, err := Parse%s(b)", name)
+		g.line("if err != nil {")
+		g.line("return nil, fmt.Errorf(%q, i, err)", "parse "+kindField(kind)+" %d: %w")
+		g.line("}")
+		g.line("")
+		g.line("res = append(res, v)")
+		g.line("}")
+		g.line("")
+		g.line("return res, nil")
+		g.line("}")
+	}
+
+	for _, f := range fields {
+		if f.Data || f.Key != "value" {
+			continue
+		}
+
+		g.valueAccessor(name, desc, source, list, spec, f)
+	}
+
+	g.blocks(name, "the "+desc, "parent *newsdoc.Block", "parent",
+		func(kind revisor.BlockKind) []*revisor.BlockSpec {
+			return conditionalBlocks(spec, kind)
+		})
+}
+
+// valueAccessor generates a function that returns the parsed value attribute
+// of the first matching block.
+func (g *goGenerator) valueAccessor(
+	name string, desc string, source string, list string,
+	spec *revisor.BlockSpec, f goField,
+) {
+	value := f.Value
+	value.Pointer = false
+
+	g.line("")
+	g.comment(fmt.Sprintf(
+		"Get%sValue returns the value of the first %s, false is returned if the block doesn't exist or the value is empty or invalid.",
+		name, desc))
+	g.line("func Get%sValue(%s) (%s, bool) {", name, source, value.Type)
+	g.line("for _, b := range %s {", list)
+	g.line("if !blockMatches(b, %q, %q, %q) {", spec.Declares.Type, spec.Declares.Rel, spec.Declares.Role)
+	g.line("continue")
+	g.line("}")
+	g.line("")
+
+	if value.Parse == "" {
+		g.line("return %s, b.Value != \"\"", value.parseExpr("value", "b.Value"))
+	} else {
+		g.line("v, err := %s", value.parseExpr("value", "b.Value"))
+		g.line("if err != nil {")
+		g.line("var zero %s", value.Type)
+		g.line("")
+		g.line("return zero, false")
+		g.line("}")
+		g.line("")
+		g.line("return v, true")
+	}
+
+	g.line("}")
+	g.line("")
+	g.line("var zero %s", value.Type)
+	g.line("")
+	g.line("return zero, false")
+	g.line("}")
+}
+
+const goHelpers = `
+func blockMatches(b newsdoc.Block, blockType string, rel string, role string) bool {
+	return (blockType == "" || b.Type == blockType) &&
+		(rel == "" || b.Rel == rel) &&
+		(role == "" || b.Role == role)
+}
+
+func parseValue[T any](name string, value string, parse func(string) (T, error)) (T, error) {
+	v, err := parse(value)
+	if err != nil {
+		var zero T
+
+		return zero, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return v, nil
+}
+
+func parseOptional[T any](name string, value string, parse func(string) (T, error)) (*T, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	v, err := parseValue(name, value, parse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func formatOptional[T any](v *T, format func(T) string) string {
+	if v == nil {
+		return ""
+	}
+
+	return format(*v)
+}
+
+func setOptional(data newsdoc.DataMap, key string, value string) {
+	if value == "" {
+		return
+	}
+
+	data[key] = value
+}
+
+func parseInt(value string) (int, error) {
+	return strconv.Atoi(value)
+}
+
+func parseFloat(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339, value)
+}
+
+func formatTime(v time.Time) string {
+	return v.Format(time.RFC3339)
+}
+`
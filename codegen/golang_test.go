@@ -0,0 +1,50 @@
+package codegen_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"testing"
+
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/codegen"
+)
+
+func TestGo(t *testing.T) {
+	testGolden(t, ".go.txt", func(w io.Writer, v *revisor.Validator) error {
+		return codegen.Go(w, v, codegen.GoOptions{})
+	})
+}
+
+func TestGoTypeCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checking generated code is slow")
+	}
+
+	for name, v := range testValidators(t) {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := codegen.Go(&buf, v, codegen.GoOptions{
+				Package: "generated",
+			})
+			mustf(t, err, "generate code")
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "generated.go", buf.Bytes(), 0)
+			mustf(t, err, "parse generated code")
+
+			conf := types.Config{
+				Importer: importer.ForCompiler(fset, "source", nil),
+			}
+
+			_, err = conf.Check("generated", fset, []*ast.File{file}, nil)
+			mustf(t, err, "type check generated code")
+		})
+	}
+}
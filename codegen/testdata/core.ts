@@ -0,0 +1,1823 @@
+// Code generated by revisor codegen ts. DO NOT EDIT.
+
+/**
+ * Place relationships
+ */
+export type CorePlaceRelationships = "capital-of" | "country" | "county" | "municipality" | "province" | "region" | "state";
+
+/**
+ * Place types
+ */
+export type CorePlaceTypes = "city" | "country" | "county" | "municipality" | "poi" | "province" | "region" | "state";
+
+/**
+ * Text block roles
+ */
+export type CoreTextRoles = "blockquote" | "heading-1" | "heading-2" | "preamble" | "vignette";
+
+/**
+ * Place
+ * A geographical location
+ */
+export interface CorePlace {
+  uuid: string;
+  type: "core/place" | "core/place#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CorePlaceLink[];
+  meta?: CorePlaceMeta[];
+  content?: never[];
+}
+
+export type CorePlaceLink = CorePlaceLinkCorePlace;
+
+export interface CorePlaceLinkCorePlace {
+  id?: string;
+  uuid: string;
+  type: "core/place";
+  title: string;
+  rel: CorePlaceRelationships;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CorePlaceMeta = CorePlaceMetaCorePosition | CorePlaceMetaCorePlaceType | CorePlaceMetaCoreAlias;
+
+export interface CorePlaceMetaCorePosition {
+  id?: string;
+  type: "core/position";
+  /**
+   * Must be a WKT geometry.
+   */
+  value: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CorePlaceMetaCorePlaceType {
+  id?: string;
+  type: "core/place-type";
+  value: CorePlaceTypes;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CorePlaceMetaCoreAlias {
+  id?: string;
+  type: "core/alias";
+  title: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Section
+ * A section for content
+ */
+export interface CoreSection {
+  uuid: string;
+  type: "core/section" | "core/section#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Topic
+ * An topic for content
+ */
+export interface CoreTopic {
+  uuid: string;
+  type: "core/topic" | "core/topic#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Story
+ * An ongoing story that gets reported on
+ */
+export interface CoreStory {
+  uuid: string;
+  type: "core/story" | "core/story#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: never[];
+  meta?: CoreStoryMeta[];
+  content?: never[];
+}
+
+export type CoreStoryMeta = CoreStoryMetaCoreDefinition;
+
+export interface CoreStoryMetaCoreDefinition {
+  id?: string;
+  type: "core/definition";
+  role: "short" | "long";
+  data: {
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Person
+ * Used to describe people
+ */
+export interface CorePerson {
+  uuid: string;
+  type: "core/person" | "core/person#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: never[];
+  meta: CorePersonMeta[];
+  content?: never[];
+}
+
+export type CorePersonMeta = CorePersonMetaCorePerson | CorePersonMetaCoreContactInfo;
+
+/**
+ * Main metadata block
+ */
+export interface CorePersonMetaCorePerson {
+  id?: string;
+  type: "core/person";
+  data?: {
+    employer?: string;
+    firstName?: string;
+    lastName?: string;
+    title?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CorePersonMetaCoreContactInfo {
+  id?: string;
+  type: "core/contact-info";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Image
+ * Image metadata
+ */
+export interface CoreImage {
+  uuid: string;
+  type: "core/image" | "core/image#template";
+  uri: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreImageLink[];
+  meta: CoreImageMeta[];
+  content?: never[];
+}
+
+export type CoreImageLink = CoreImageLinkAuthorCoreAuthor | CoreImageLinkSource | CoreImageLinkBatch;
+
+export interface CoreImageLinkAuthorCoreAuthor {
+  id?: string;
+  uuid: string;
+  type: "core/author";
+  title: string;
+  rel: "author";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * External source (if any)
+ */
+export interface CoreImageLinkSource {
+  id?: string;
+  rel: "source";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreImageLinkBatch {
+  id?: string;
+  uri: string;
+  rel: "batch";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreImageMeta = CoreImageMetaCoreImage;
+
+export interface CoreImageMetaCoreImage {
+  id?: string;
+  type: "core/image";
+  data: {
+    copyright?: string;
+    credit?: string;
+    filename?: string;
+    /**
+     * Must be a integer value.
+     */
+    height: string;
+    instructions?: string;
+    mimeType?: string;
+    /**
+     * Must be a RFC3339 timestamp.
+     */
+    photoDate?: string;
+    source?: string;
+    text?: string;
+    /**
+     * Must be a integer value.
+     */
+    width: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Author
+ * An author document used for f.ex. bylines
+ */
+export interface CoreAuthor {
+  uuid: string;
+  type: "core/author" | "core/author#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreAuthorLink[];
+  meta?: CoreAuthorMeta[];
+  content?: never[];
+}
+
+export type CoreAuthorLink = CoreAuthorLinkSameAsXImidUser | CoreAuthorLinkSameAsTtAuthor;
+
+/**
+ * Association with NavigaID user account
+ */
+export interface CoreAuthorLinkSameAsXImidUser {
+  id?: string;
+  uri: string;
+  type: "x-imid/user";
+  rel: "same-as";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Same as TT Author
+ * Marks the author as a special TT author
+ */
+export interface CoreAuthorLinkSameAsTtAuthor {
+  id?: string;
+  uri: string;
+  type: "tt/author";
+  title: string;
+  rel: "same-as";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreAuthorMeta = CoreAuthorMetaCoreNote | CoreAuthorMetaCoreAuthor | CoreAuthorMetaCoreContactInfo;
+
+export interface CoreAuthorMetaCoreNote {
+  id?: string;
+  type: "core/note";
+  role: "public" | "internal";
+  data: {
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreAuthorMetaCoreAuthor {
+  id?: string;
+  type: "core/author";
+  data: {
+    firstName: string;
+    initials?: string;
+    lastName: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * TT contact extensions
+ * TODO: why the duplicate signature/initials/short desc?
+ */
+export interface CoreAuthorMetaCoreContactInfo {
+  id?: string;
+  type: "core/contact-info";
+  role: "home" | "office";
+  data?: {
+    /**
+     * TODO: only in 83d072c8-fb07-47e2-9c5b-85f4453835f6?
+     */
+    city?: string;
+    /**
+     * TODO: only in 83d072c8-fb07-47e2-9c5b-85f4453835f6?
+     */
+    country?: string;
+    email?: string;
+    initials?: string;
+    mobile?: string;
+    name?: string;
+    phone?: string;
+    /**
+     * TODO: only in 83d072c8-fb07-47e2-9c5b-85f4453835f6?
+     */
+    postalCode?: string;
+    signature?: string;
+    /**
+     * TODO: only in 83d072c8-fb07-47e2-9c5b-85f4453835f6?
+     */
+    streetAddress?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Category
+ * A category for content
+ */
+export interface CoreCategory {
+  uuid: string;
+  type: "core/category" | "core/category#template";
+  uri: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreCategoryLink[];
+  meta?: CoreCategoryMeta[];
+  content?: never[];
+}
+
+export type CoreCategoryLink = CoreCategoryLinkBroader | CoreCategoryLinkSameAsIptcMediatopic;
+
+export interface CoreCategoryLinkBroader {
+  id?: string;
+  uuid?: string;
+  uri?: string;
+  type: string;
+  rel: "broader";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreCategoryLinkSameAsIptcMediatopic {
+  id?: string;
+  uri: string;
+  type: "iptc/mediatopic";
+  rel: "same-as";
+  data: {
+    /**
+     * Must be a integer value.
+     */
+    id: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreCategoryMeta = CoreCategoryMetaCoreDefinition;
+
+export interface CoreCategoryMetaCoreDefinition {
+  id?: string;
+  type: "core/definition";
+  role: "short" | "long";
+  data: {
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Channel
+ * A publication channel
+ */
+export interface CoreChannel {
+  uuid: string;
+  type: "core/channel" | "core/channel#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Factbox
+ * A factbox that can be used in articles
+ */
+export interface CoreFactbox {
+  uuid: string;
+  type: "core/factbox" | "core/factbox#template";
+  uri?: string;
+  url?: string;
+  title: string;
+  language?: string;
+  links?: never[];
+  meta?: CoreFactboxMeta[];
+  content?: CoreFactboxContent[];
+}
+
+export type CoreFactboxMeta = CoreFactboxMetaCoreFactbox;
+
+export interface CoreFactboxMetaCoreFactbox {
+  id?: string;
+  type: "core/factbox";
+  data?: {
+    byline?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreFactboxContent = CoreFactboxContentCoreText | CoreFactboxContentCoreUnorderedList | CoreFactboxContentCoreOrderedList;
+
+export interface CoreFactboxContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreFactboxContentCoreUnorderedList {
+  id?: string;
+  type: "core/unordered-list";
+  links?: never[];
+  meta?: never[];
+  content?: CoreFactboxContentCoreUnorderedListContent[];
+}
+
+export type CoreFactboxContentCoreUnorderedListContent = CoreFactboxContentCoreUnorderedListContentCoreText;
+
+export interface CoreFactboxContentCoreUnorderedListContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreFactboxContentCoreOrderedList {
+  id?: string;
+  type: "core/ordered-list";
+  links?: never[];
+  meta?: never[];
+  content?: CoreFactboxContentCoreOrderedListContent[];
+}
+
+export type CoreFactboxContentCoreOrderedListContent = CoreFactboxContentCoreOrderedListContentCoreText;
+
+export interface CoreFactboxContentCoreOrderedListContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Article
+ * An editorial article
+ */
+export interface CoreArticle {
+  uuid: string;
+  type: "core/article" | "core/article#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreArticleLink[];
+  meta: CoreArticleMeta[];
+  content?: CoreArticleContent[];
+}
+
+export type CoreArticleLink = CoreArticleLinkAuthorCoreAuthor | CoreArticleLinkSectionCoreSection | CoreArticleLinkSubjectCoreStory | CoreArticleLinkChannelCoreChannel | CoreArticleLinkPremiumCorePremium | CoreArticleLinkSubjectCorePerson | CoreArticleLinkSubjectCoreCategory | CoreArticleLinkContentSizeCoreContentSize | CoreArticleLinkSourceCoreContentSource | CoreArticleLinkSourceCoreArticle | CoreArticleLinkAssignmentCoreAssignment | CoreArticleLinkSameAsTtEvent | CoreArticleLinkSubtype | CoreArticleLinkAlternateTtAltId;
+
+/**
+ * Author byline
+ */
+export interface CoreArticleLinkAuthorCoreAuthor {
+  id?: string;
+  uuid?: string;
+  type: "core/author";
+  title: string;
+  rel: "author";
+  data?: {
+    email?: string;
+    firstName?: string;
+    lastName?: string;
+    longDescription?: string;
+    phone?: string;
+    shortDescription?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Section
+ */
+export interface CoreArticleLinkSectionCoreSection {
+  id?: string;
+  uuid: string;
+  type: "core/section";
+  title?: string;
+  rel: "section";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Story
+ */
+export interface CoreArticleLinkSubjectCoreStory {
+  id?: string;
+  uuid: string;
+  type: "core/story";
+  title?: string;
+  rel: "subject";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Publishing channel
+ */
+export interface CoreArticleLinkChannelCoreChannel {
+  id?: string;
+  uuid: string;
+  type: "core/channel";
+  title?: string;
+  rel: "channel";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Premium
+ */
+export interface CoreArticleLinkPremiumCorePremium {
+  id?: string;
+  uri: string;
+  type: "core/premium";
+  title?: string;
+  rel: "premium";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Person
+ * A person that is a subject of the article
+ */
+export interface CoreArticleLinkSubjectCorePerson {
+  id?: string;
+  uuid: string;
+  type: "core/person";
+  title?: string;
+  rel: "subject";
+  data?: {
+    email?: string;
+    firstName?: string;
+    lastName?: string;
+    phone?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Category
+ */
+export interface CoreArticleLinkSubjectCoreCategory {
+  id?: string;
+  uuid: string;
+  uri?: string;
+  type: "core/category";
+  title?: string;
+  rel: "subject";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Content size
+ * Content size classification, described by URI
+ */
+export interface CoreArticleLinkContentSizeCoreContentSize {
+  id?: string;
+  uri: "core://content-size/article/medium";
+  type: "core/content-size";
+  title?: string;
+  rel: "content-size";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Content source
+ * The organisation that is the source of the content
+ */
+export interface CoreArticleLinkSourceCoreContentSource {
+  id?: string;
+  uri: string;
+  type: "core/content-source";
+  rel: "source";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Article source
+ * Points to the original article if this one is a copy
+ */
+export interface CoreArticleLinkSourceCoreArticle {
+  id?: string;
+  uuid: string;
+  type: "core/article";
+  rel: "source";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Assignment
+ * A link to the assignment that the article was produced for
+ */
+export interface CoreArticleLinkAssignmentCoreAssignment {
+  id?: string;
+  uuid: string;
+  type: "core/assignment";
+  rel: "assignment";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Same as TT event
+ * TODO: what is this? Maybe a one-off, was in 69da3ef5-f1b0-5caf-b846-ca5682b9adf9
+ */
+export interface CoreArticleLinkSameAsTtEvent {
+  id?: string;
+  uri: string;
+  type: "tt/event";
+  rel: "same-as";
+  data: {
+    id: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Subtype
+ */
+export interface CoreArticleLinkSubtype {
+  id?: string;
+  uri: string;
+  rel: "subtype";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Alternate ID
+ * TODO: is this actually used for live data? See stage/df6ebaba-b3fc-40ff-9ad2-19f953eb0c6a
+ */
+export interface CoreArticleLinkAlternateTtAltId {
+  id?: string;
+  uri: string;
+  type: "tt/alt-id";
+  rel: "alternate";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleMeta = CoreArticleMetaCoreNewsvalue | CoreArticleMetaCoreTeaser | CoreArticleMetaTtSlugline | CoreArticleMetaTtSector;
+
+export interface CoreArticleMetaCoreNewsvalue {
+  id?: string;
+  type: "core/newsvalue";
+  /**
+   * The assigned newsvalue
+   * Must be a integer value.
+   */
+  value: string;
+  data?: {
+    /**
+     * Duration in seconds, can represent the halving time for the score in a scoring algorithm.
+     * Must be a integer value.
+     */
+    duration?: string;
+    /**
+     * The cut-off time that the document has no newsvalue
+     * Must be a RFC3339 timestamp.
+     */
+    end?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleMetaCoreTeaser {
+  id?: string;
+  type: "core/teaser";
+  title: string;
+  data: {
+    text: string;
+  };
+  links?: CoreArticleMetaCoreTeaserLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleMetaCoreTeaserLink = CoreArticleMetaCoreTeaserLinkImageCoreImage;
+
+export interface CoreArticleMetaCoreTeaserLinkImageCoreImage {
+  id?: string;
+  uuid: string;
+  uri: string;
+  type: "core/image";
+  rel: "image";
+  data: {
+    /**
+     * Must be a integer value.
+     */
+    height: string;
+    imageInstructions?: string;
+    /**
+     * Must be a integer value.
+     */
+    width: string;
+  };
+  links?: CoreArticleMetaCoreTeaserLinkImageCoreImageLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleMetaCoreTeaserLinkImageCoreImageLink = CoreArticleMetaCoreTeaserLinkImageCoreImageLinkCropXImCrop;
+
+export interface CoreArticleMetaCoreTeaserLinkImageCoreImageLinkCropXImCrop {
+  id?: string;
+  uri: string;
+  type: "x-im/crop";
+  title: string;
+  rel: "crop";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleMetaTtSlugline {
+  id?: string;
+  type: "tt/slugline";
+  value: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Sector
+ * TODO: what is sector?
+ * @deprecated Use core/section instead
+ */
+export interface CoreArticleMetaTtSector {
+  id?: string;
+  type: "tt/sector";
+  value: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleContent = CoreArticleContentCoreText | CoreArticleContentCoreImage | CoreArticleContentCoreYoutube | CoreArticleContentCoreHtmlembed | CoreArticleContentCoreUnorderedList | CoreArticleContentCoreOrderedList | CoreArticleContentCoreFactbox | CoreArticleContentCoreTable | CoreArticleContentCoreSocialembed | CoreArticleContentTtDateline | CoreArticleContentTtQuestion | CoreArticleContentTtVisual;
+
+/**
+ * A standard text block
+ */
+export interface CoreArticleContentCoreText {
+  id?: string;
+  type: "core/text";
+  role?: CoreTextRoles | "";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Core image block
+ */
+export interface CoreArticleContentCoreImage {
+  id?: string;
+  uuid: string;
+  type: "core/image";
+  links: CoreArticleContentCoreImageLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleContentCoreImageLink = CoreArticleContentCoreImageLinkSelfCoreImage;
+
+export interface CoreArticleContentCoreImageLinkSelfCoreImage {
+  id?: string;
+  uuid: string;
+  uri: string;
+  type: "core/image";
+  rel: "self";
+  data: {
+    alttext?: string;
+    /**
+     * Must be a integer value.
+     */
+    height: string;
+    text?: string;
+    /**
+     * Must be a integer value.
+     */
+    width: string;
+  };
+  links?: CoreArticleContentCoreImageLinkSelfCoreImageLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleContentCoreImageLinkSelfCoreImageLink = CoreArticleContentCoreImageLinkSelfCoreImageLinkAuthorCoreAuthor;
+
+export interface CoreArticleContentCoreImageLinkSelfCoreImageLinkAuthorCoreAuthor {
+  id?: string;
+  uuid: string;
+  type: "core/author";
+  title: string;
+  rel: "author";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreYoutube {
+  id?: string;
+  uri: string;
+  url: string;
+  type: "core/youtube";
+  data: {
+    /**
+     * Must be a integer value.
+     */
+    start: string;
+  };
+  links?: CoreArticleContentCoreYoutubeLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleContentCoreYoutubeLink = CoreArticleContentCoreYoutubeLinkAlternateTextHtml | CoreArticleContentCoreYoutubeLinkAlternateImageJpg;
+
+export interface CoreArticleContentCoreYoutubeLinkAlternateTextHtml {
+  id?: string;
+  url: string;
+  type: "text/html";
+  title: string;
+  rel: "alternate";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreYoutubeLinkAlternateImageJpg {
+  id?: string;
+  url: string;
+  type: "image/jpg";
+  rel: "alternate";
+  data: {
+    /**
+     * Must be a integer value.
+     */
+    height: string;
+    /**
+     * Must be a integer value.
+     */
+    width: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreHtmlembed {
+  id?: string;
+  type: "core/htmlembed";
+  data: {
+    /**
+     * TODO: add 'anything goes' policy to revisor to be able to use the html format validation for free-form html
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreUnorderedList {
+  id?: string;
+  type: "core/unordered-list";
+  links?: never[];
+  meta?: never[];
+  content?: CoreArticleContentCoreUnorderedListContent[];
+}
+
+export type CoreArticleContentCoreUnorderedListContent = CoreArticleContentCoreUnorderedListContentCoreText;
+
+export interface CoreArticleContentCoreUnorderedListContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreOrderedList {
+  id?: string;
+  type: "core/ordered-list";
+  links?: never[];
+  meta?: never[];
+  content?: CoreArticleContentCoreOrderedListContent[];
+}
+
+export type CoreArticleContentCoreOrderedListContent = CoreArticleContentCoreOrderedListContentCoreText;
+
+export interface CoreArticleContentCoreOrderedListContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreFactbox {
+  id?: string;
+  uuid?: string;
+  type: "core/factbox";
+  title: string;
+  data?: {
+    byline?: string;
+    /**
+     * Must be a RFC3339 timestamp.
+     */
+    locally_changed?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: CoreArticleContentCoreFactboxContent[];
+}
+
+export type CoreArticleContentCoreFactboxContent = CoreArticleContentCoreFactboxContentCoreText | CoreArticleContentCoreFactboxContentCoreUnorderedList | CoreArticleContentCoreFactboxContentCoreOrderedList;
+
+export interface CoreArticleContentCoreFactboxContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreFactboxContentCoreUnorderedList {
+  id?: string;
+  type: "core/unordered-list";
+  links?: never[];
+  meta?: never[];
+  content?: CoreArticleContentCoreFactboxContentCoreUnorderedListContent[];
+}
+
+export type CoreArticleContentCoreFactboxContentCoreUnorderedListContent = CoreArticleContentCoreFactboxContentCoreUnorderedListContentCoreText;
+
+export interface CoreArticleContentCoreFactboxContentCoreUnorderedListContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreArticleContentCoreFactboxContentCoreOrderedList {
+  id?: string;
+  type: "core/ordered-list";
+  links?: never[];
+  meta?: never[];
+  content?: CoreArticleContentCoreFactboxContentCoreOrderedListContent[];
+}
+
+export type CoreArticleContentCoreFactboxContentCoreOrderedListContent = CoreArticleContentCoreFactboxContentCoreOrderedListContentCoreText;
+
+export interface CoreArticleContentCoreFactboxContentCoreOrderedListContentCoreText {
+  id?: string;
+  type: "core/text";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Table
+ */
+export interface CoreArticleContentCoreTable {
+  id?: string;
+  type: "core/table";
+  data: {
+    caption?: string;
+    meta: string;
+    /**
+     * Must be a html string.
+     */
+    tbody: string;
+    /**
+     * Must be a html string.
+     */
+    tfoot?: string;
+    /**
+     * Must be a html string.
+     */
+    thead?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Social embed
+ */
+export interface CoreArticleContentCoreSocialembed {
+  id?: string;
+  type: "core/socialembed";
+  links?: CoreArticleContentCoreSocialembedLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleContentCoreSocialembedLink = CoreArticleContentCoreSocialembedLinkSelfCoreTweet | CoreArticleContentCoreSocialembedLinkSelfCoreInstagram | CoreArticleContentCoreSocialembedLinkAlternateTextHtml | CoreArticleContentCoreSocialembedLinkAlternateImageJpg;
+
+/**
+ * Twitter embed link
+ */
+export interface CoreArticleContentCoreSocialembedLinkSelfCoreTweet {
+  id?: string;
+  uri: string;
+  url: string;
+  type: "core/tweet";
+  rel: "self";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Instagram embed link
+ */
+export interface CoreArticleContentCoreSocialembedLinkSelfCoreInstagram {
+  id?: string;
+  uri: string;
+  url: string;
+  type: "core/instagram";
+  rel: "self";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Embed location link
+ */
+export interface CoreArticleContentCoreSocialembedLinkAlternateTextHtml {
+  id?: string;
+  url: string;
+  type: "text/html";
+  title: string;
+  rel: "alternate";
+  data: {
+    context: string;
+    provider: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Embed image
+ */
+export interface CoreArticleContentCoreSocialembedLinkAlternateImageJpg {
+  id?: string;
+  url: string;
+  type: "image/jpg";
+  rel: "alternate";
+  data: {
+    /**
+     * Must be a integer value.
+     */
+    height: string;
+    /**
+     * Must be a integer value.
+     */
+    width: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Dateline
+ * Deprecated in favour of vignette
+ * @deprecated Use core/text vignette instead
+ */
+export interface CoreArticleContentTtDateline {
+  id?: string;
+  type: "tt/dateline";
+  data: {
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Question
+ */
+export interface CoreArticleContentTtQuestion {
+  id?: string;
+  type: "tt/question";
+  data: {
+    /**
+     * Must be a html string.
+     */
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * TT visual element
+ * This can be either a picture or a graphic
+ */
+export interface CoreArticleContentTtVisual {
+  id?: string;
+  type: "tt/visual";
+  data: {
+    caption: string;
+  };
+  links?: CoreArticleContentTtVisualLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreArticleContentTtVisualLink = CoreArticleContentTtVisualLinkSelf;
+
+export interface CoreArticleContentTtVisualLinkSelf {
+  id?: string;
+  uri: string;
+  url: string;
+  type: "tt/picture" | "tt/graphic";
+  rel: "self";
+  data: {
+    credit: string;
+    /**
+     * Must be a integer value.
+     */
+    height: string;
+    /**
+     * Must be a float value.
+     * @deprecated Not supported anymore
+     */
+    hiresScale?: string;
+    /**
+     * Must be a integer value.
+     */
+    width: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Contacts group
+ * A group used to categorise contacts
+ */
+export interface CoreGroup {
+  uuid: string;
+  type: "core/group" | "core/group#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreGroupLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreGroupLink = CoreGroupLinkAlternateTtOldContactDb;
+
+/**
+ * Old DB reference
+ */
+export interface CoreGroupLinkAlternateTtOldContactDb {
+  id?: string;
+  uri: string;
+  type: "tt/old-contact-db";
+  rel: "alternate";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Contact information
+ * A useful contact
+ */
+export interface CoreContact {
+  uuid: string;
+  type: "core/contact" | "core/contact#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreContactLink[];
+  meta: CoreContactMeta[];
+  content?: never[];
+}
+
+export type CoreContactLink = CoreContactLinkGroupCoreGroup;
+
+/**
+ * Groups
+ * The groups that the contact is assigned to
+ */
+export interface CoreContactLinkGroupCoreGroup {
+  id?: string;
+  uuid: string;
+  type: "core/group";
+  title: string;
+  rel: "group";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreContactMeta = CoreContactMetaCoreDefinition | CoreContactMetaCoreContact | CoreContactMetaCoreContactInfo;
+
+export interface CoreContactMetaCoreDefinition {
+  id?: string;
+  type: "core/definition";
+  role: "short" | "long";
+  data: {
+    text: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Main metadata block
+ */
+export interface CoreContactMetaCoreContact {
+  id?: string;
+  type: "core/contact";
+  data?: {
+    employer?: string;
+    firstName?: string;
+    lastName?: string;
+    title?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreContactMetaCoreContactInfo {
+  id?: string;
+  type: "core/contact-info";
+  role: "home" | "office";
+  data?: {
+    address?: string;
+    country?: string;
+    email?: string;
+    locality?: string;
+    mobile?: string;
+    name?: string;
+    phone?: string;
+  };
+  links?: CoreContactMetaCoreContactInfoLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreContactMetaCoreContactInfoLink = CoreContactMetaCoreContactInfoLinkSeeAlsoTextHtml;
+
+/**
+ * Webpage
+ */
+export interface CoreContactMetaCoreContactInfoLinkSeeAlsoTextHtml {
+  id?: string;
+  url: string;
+  type: "text/html";
+  rel: "see-also";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Calendar event
+ * A calendar event
+ */
+export interface CoreEvent {
+  uuid: string;
+  type: "core/event" | "core/event#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreEventLink[];
+  meta: CoreEventMeta[];
+  content?: never[];
+}
+
+export type CoreEventLink = CoreEventLinkSectionCoreSection | CoreEventLinkOrganiserCoreOrganiser | CoreEventLinkParticipantCoreAuthor | CoreEventLinkPlaceCorePlace | CoreEventLinkStoryCoreStory | CoreEventLinkCategoryCoreCategory | CoreEventLinkSubjectCoreTopic | CoreEventLinkLocationCoreGeoPoint | CoreEventLinkCopyrightholder | CoreEventLinkOrganiserTtOrganiser | CoreEventLinkParticipantTtParticipant;
+
+/**
+ * Section
+ */
+export interface CoreEventLinkSectionCoreSection {
+  id?: string;
+  uuid: string;
+  type: "core/section";
+  title?: string;
+  rel: "section";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Organiser
+ */
+export interface CoreEventLinkOrganiserCoreOrganiser {
+  id?: string;
+  uuid: string;
+  type: "core/organiser";
+  title?: string;
+  rel: "organiser";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Participant
+ */
+export interface CoreEventLinkParticipantCoreAuthor {
+  id?: string;
+  uuid: string;
+  type: "core/author";
+  title: string;
+  rel: "participant";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Place
+ */
+export interface CoreEventLinkPlaceCorePlace {
+  id?: string;
+  uuid: string;
+  type: "core/place";
+  title: string;
+  rel: "place";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Story
+ */
+export interface CoreEventLinkStoryCoreStory {
+  id?: string;
+  uuid: string;
+  type: "core/story";
+  title?: string;
+  rel: "story";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Category
+ */
+export interface CoreEventLinkCategoryCoreCategory {
+  id?: string;
+  uuid: string;
+  uri?: string;
+  type: "core/category";
+  title?: string;
+  rel: "category";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Topic
+ */
+export interface CoreEventLinkSubjectCoreTopic {
+  id?: string;
+  uuid: string;
+  type: "core/topic";
+  title?: string;
+  rel: "subject";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Geo position
+ */
+export interface CoreEventLinkLocationCoreGeoPoint {
+  id?: string;
+  uri: string;
+  type: "core/geo-point";
+  title: string;
+  rel: "location";
+  data: {
+    country: string;
+    extraInfo: string;
+    /**
+     * Must be a WKT geometry.
+     */
+    geometry: string;
+    locality: string;
+    name: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Copyright holder
+ * TODO: do we really need this?
+ */
+export interface CoreEventLinkCopyrightholder {
+  id?: string;
+  title: string;
+  rel: "copyrightholder";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * TT Organiser
+ * TODO: is this good data, or just noise?
+ */
+export interface CoreEventLinkOrganiserTtOrganiser {
+  id?: string;
+  uri: string;
+  type: "tt/organiser";
+  title: string;
+  rel: "organiser";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * TT Participant
+ * TODO: is this good data, or just noise?
+ */
+export interface CoreEventLinkParticipantTtParticipant {
+  id?: string;
+  uri: string;
+  type: "tt/participant";
+  title: string;
+  rel: "participant";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreEventMeta = CoreEventMetaCoreEvent | CoreEventMetaCoreNewsvalue;
+
+/**
+ * Main metadata block
+ */
+export interface CoreEventMetaCoreEvent {
+  id?: string;
+  type: "core/event";
+  data: {
+    dateGranularity: "date" | "datetime";
+    /**
+     * Must be a RFC3339 timestamp.
+     */
+    end: string;
+    registration: string;
+    /**
+     * Must be a RFC3339 timestamp.
+     */
+    start: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface CoreEventMetaCoreNewsvalue {
+  id?: string;
+  type: "core/newsvalue";
+  /**
+   * The assigned newsvalue
+   * Must be a integer value.
+   */
+  value: string;
+  data?: {
+    /**
+     * Duration in seconds, can represent the halving time for the score in a scoring algorithm.
+     * Must be a integer value.
+     */
+    duration?: string;
+    /**
+     * The cut-off time that the document has no newsvalue
+     * Must be a RFC3339 timestamp.
+     */
+    end?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Organiser
+ * A document describing an organisation
+ */
+export interface CoreOrganiser {
+  uuid: string;
+  type: "core/organiser" | "core/organiser#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: CoreOrganiserLink[];
+  meta: CoreOrganiserMeta[];
+  content?: never[];
+}
+
+export type CoreOrganiserLink = CoreOrganiserLinkSeeAlsoTextHtml | CoreOrganiserLinkSeeAlsoCoreSocialFacebook | CoreOrganiserLinkSeeAlsoCoreSocialTwitter;
+
+/**
+ * Browseable link for the organiser
+ * Usually the homepage or other resources that describes the organiser
+ */
+export interface CoreOrganiserLinkSeeAlsoTextHtml {
+  id?: string;
+  url: string;
+  type: "text/html";
+  rel: "see-also";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Facebook page for the organiser
+ */
+export interface CoreOrganiserLinkSeeAlsoCoreSocialFacebook {
+  id?: string;
+  url: string;
+  type: "core/social+facebook";
+  rel: "see-also";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Twitter account for the organiser
+ */
+export interface CoreOrganiserLinkSeeAlsoCoreSocialTwitter {
+  id?: string;
+  type: "core/social+twitter";
+  rel: "see-also";
+  data: {
+    handle: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreOrganiserMeta = CoreOrganiserMetaCoreContactInfo;
+
+/**
+ * Organiser contact information
+ */
+export interface CoreOrganiserMetaCoreContactInfo {
+  id?: string;
+  type: "core/contact-info";
+  data?: {
+    city?: string;
+    country?: string;
+    email?: string;
+    phone?: string;
+    postalCode?: string;
+    streetAddress?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export interface TtGrattis {
+  uuid: string;
+  type: "tt/grattis" | "tt/grattis#template";
+  uri?: string;
+  url?: string;
+  title?: string;
+  language?: string;
+  links?: TtGrattisLink[];
+  meta: TtGrattisMeta[];
+  content?: never[];
+}
+
+export type TtGrattisLink = TtGrattisLinkAlternateTtOldContactDb;
+
+/**
+ * Old DB reference
+ */
+export interface TtGrattisLinkAlternateTtOldContactDb {
+  id?: string;
+  uri: string;
+  type: "tt/old-contact-db";
+  rel: "alternate";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type TtGrattisMeta = TtGrattisMetaCoreContact | TtGrattisMetaTtBirthday;
+
+/**
+ * Contact information
+ */
+export interface TtGrattisMetaCoreContact {
+  id?: string;
+  type: "core/contact";
+  data?: {
+    firstName?: string;
+    lastName?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+/**
+ * Birthday date in UTC
+ */
+export interface TtGrattisMetaTtBirthday {
+  id?: string;
+  type: "tt/birthday";
+  /**
+   * Must be a RFC3339 timestamp.
+   */
+  value: string;
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type AnyDocument = CorePlace | CoreSection | CoreTopic | CoreStory | CorePerson | CoreImage | CoreAuthor | CoreCategory | CoreChannel | CoreFactbox | CoreArticle | CoreGroup | CoreContact | CoreEvent | CoreOrganiser | TtGrattis;
@@ -0,0 +1,61 @@
+// Code generated by revisor codegen ts. DO NOT EDIT.
+
+/**
+ * Deprecated values: "place://old"
+ */
+export type CorePlaceUris = "place://new" | "place://old";
+
+/**
+ * Place
+ * @deprecated Stop sending these plz
+ */
+export interface CorePlace {
+  uuid: string;
+  type: "core/place";
+  uri?: string;
+  url?: string;
+  /**
+   * @deprecated What places need names anyway?
+   */
+  title?: string;
+  language?: string;
+  links?: never[];
+  meta?: CorePlaceMeta[];
+  content?: never[];
+}
+
+export type CorePlaceMeta = CorePlaceMetaCorePlace;
+
+export interface CorePlaceMetaCorePlace {
+  id?: string;
+  uri?: CorePlaceUris | "";
+  type: "core/place";
+  /**
+   * @deprecated Let's just skip roles
+   */
+  role?: string;
+  data?: {
+    /**
+     * Must be a WKT geometry.
+     */
+    area?: string;
+    /**
+     * Must be a WKT geometry.
+     */
+    position?: string;
+    /**
+     * Must be a WKT geometry.
+     * @deprecated Too ambitious, don't want
+     */
+    position_3d?: string;
+    /**
+     * Must be a WKT geometry.
+     */
+    road?: string;
+  };
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type AnyDocument = CorePlace;
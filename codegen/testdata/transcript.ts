@@ -0,0 +1,112 @@
+// Code generated by revisor codegen ts. DO NOT EDIT.
+
+/**
+ * Dummy example
+ * Deprecated values: "https://example.com/transcipt"
+ */
+export type ExampleValidUrls = "https://example.com/transcipt" | "https://example.com/transcript";
+
+/**
+ * Transcript
+ * A transcript of an interview, talk, or similar voice content
+ */
+export interface CoreTranscript {
+  uuid: string;
+  type: "core/transcript";
+  uri: string;
+  url: ExampleValidUrls;
+  title?: string;
+  language?: string;
+  links: CoreTranscriptLink[];
+  meta?: CoreTranscriptMeta[];
+  content?: CoreTranscriptContent[];
+}
+
+export type CoreTranscriptLink = CoreTranscriptLinkSourceAudio;
+
+/**
+ * Source audio
+ * A reference to the source audio file
+ */
+export interface CoreTranscriptLinkSourceAudio {
+  id?: string;
+  uri: string;
+  url?: ExampleValidUrls | "";
+  type: string;
+  rel: "source-audio";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreTranscriptMeta = CoreTranscriptMetaSpeakerCorePerson;
+
+/**
+ * Speaker
+ * A speaker that has been identified in the audio
+ */
+export interface CoreTranscriptMetaSpeakerCorePerson {
+  id?: string;
+  /**
+   * Speaker entity UUID
+   * Must be a uuid.
+   */
+  uuid?: string;
+  type: "core/person";
+  title: string;
+  rel: "speaker";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreTranscriptContent = CoreTranscriptContentCoreTranscriptionSegment;
+
+/**
+ * Transcribed segment
+ */
+export interface CoreTranscriptContentCoreTranscriptionSegment {
+  id?: string;
+  type: "core/transcription-segment";
+  sensitivity: "internal" | "public";
+  data: {
+    /**
+     * Must be a float value.
+     */
+    end_sec: string;
+    /**
+     * Must be a boolean.
+     */
+    proofread: string;
+    /**
+     * Must be a integer value.
+     */
+    speaker?: string;
+    /**
+     * Must be a float value.
+     */
+    start_sec: string;
+    text: string;
+  };
+  links: CoreTranscriptContentCoreTranscriptionSegmentLink[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type CoreTranscriptContentCoreTranscriptionSegmentLink = CoreTranscriptContentCoreTranscriptionSegmentLinkSourceAudio;
+
+/**
+ * Source audio
+ * A reference to the source audio file
+ */
+export interface CoreTranscriptContentCoreTranscriptionSegmentLinkSourceAudio {
+  id?: string;
+  uri: string;
+  type: string;
+  rel: "source-audio";
+  links?: never[];
+  meta?: never[];
+  content?: never[];
+}
+
+export type AnyDocument = CoreTranscript;
@@ -0,0 +1,362 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ttab/revisor"
+)
+
+// TypeScript writes TypeScript declarations for the document types declared
+// in the validator. Every document type and block gets an interface, and the
+// blocks of a document or block are typed as a union discriminated by the
+// block signature. Enums are declared as string literal unions.
+func TypeScript(w io.Writer, v *revisor.Validator) error {
+	g := tsGenerator{
+		names:   make(nameSet),
+		enums:   make(map[string]string),
+		formats: v.Formats(),
+	}
+
+	specs := v.DocumentSpecs()
+	docNames := make([]string, len(specs))
+
+	g.names.unique("AnyDocument")
+
+	for i, spec := range specs {
+		docNames[i] = g.names.unique(identifier(spec.Type))
+	}
+
+	enums := v.Enums()
+
+	for _, e := range enums {
+		g.enums[e.ID] = g.names.unique(identifier(e.ID))
+	}
+
+	g.line("// Code generated by revisor codegen ts. DO NOT EDIT.")
+
+	for _, e := range enums {
+		g.enumType(e)
+	}
+
+	for i, spec := range specs {
+		g.documentType(docNames[i], spec)
+	}
+
+	g.line("")
+
+	if len(docNames) == 0 {
+		g.line("export type AnyDocument = never;")
+	} else {
+		g.line("export type AnyDocument = %s;", strings.Join(docNames, " | "))
+	}
+
+	_, err := io.WriteString(w, g.out.String())
+	if err != nil {
+		return fmt.Errorf("write declarations: %w", err)
+	}
+
+	return nil
+}
+
+type tsGenerator struct {
+	out     strings.Builder
+	names   nameSet
+	enums   map[string]string
+	formats *revisor.FormatRegistry
+}
+
+func (g *tsGenerator) line(format string, a ...any) {
+	if len(a) > 0 {
+		format = fmt.Sprintf(format, a...)
+	}
+
+	g.out.WriteString(format)
+	g.out.WriteString("\n")
+}
+
+// comment writes a JSDoc comment, empty lines are dropped.
+func (g *tsGenerator) comment(
+	indent string, deprecated *revisor.Deprecation, lines ...string,
+) {
+	var content []string
+
+	for _, l := range lines {
+		if l == "" {
+			continue
+		}
+
+		content = append(content, strings.Split(l, "\n")...)
+	}
+
+	if deprecated != nil {
+		content = append(content, strings.TrimSpace("@deprecated "+deprecated.Description()))
+	}
+
+	if len(content) == 0 {
+		return
+	}
+
+	g.line("%s/**", indent)
+
+	for _, l := range content {
+		g.line("%s * %s", indent, strings.ReplaceAll(l, "*/", "*\\/"))
+	}
+
+	g.line("%s */", indent)
+}
+
+func (g *tsGenerator) enumType(e revisor.EnumSpec) {
+	var (
+		values     []string
+		deprecated []string
+	)
+
+	for _, v := range e.Values {
+		if v.Forbidden {
+			continue
+		}
+
+		values = append(values, strconv.Quote(v.Value))
+
+		if v.Deprecated != nil {
+			deprecated = append(deprecated, strconv.Quote(v.Value))
+		}
+	}
+
+	var note string
+
+	if len(deprecated) > 0 {
+		note = "Deprecated values: " + strings.Join(deprecated, ", ")
+	}
+
+	union := "never"
+	if len(values) > 0 {
+		union = strings.Join(values, " | ")
+	}
+
+	g.line("")
+	g.comment("", nil, e.Name, e.Description, note)
+	g.line("export type %s = %s;", g.enums[e.ID], union)
+}
+
+func (g *tsGenerator) documentType(name string, spec *revisor.DocumentSpec) {
+	types := []string{strconv.Quote(spec.Type)}
+
+	for _, t := range spec.VariantTypes {
+		types = append(types, strconv.Quote(t))
+	}
+
+	g.line("")
+	g.comment("", spec.Deprecated, spec.Name, spec.Description)
+	g.line("export interface %s {", name)
+	g.line("  uuid: string;")
+	g.line("  type: %s;", strings.Join(types, " | "))
+
+	for _, k := range documentAttributes {
+		c, declared := spec.Attributes.Constraints[k]
+		if !declared {
+			g.line("  %s?: string;", k)
+
+			continue
+		}
+
+		g.property("  ", k, c, c.AllowEmpty)
+	}
+
+	var children []func()
+
+	for _, kind := range blockKinds {
+		children = append(children,
+			g.blockList(name, kind, spec.Blocks(kind), spec.Blocks(kind)))
+	}
+
+	g.line("}")
+
+	for _, fn := range children {
+		fn()
+	}
+}
+
+// blockList writes the property for a list of blocks and returns a function
+// that writes the declarations of the block types.
+func (g *tsGenerator) blockList(
+	parent string, kind revisor.BlockKind,
+	specs []*revisor.BlockSpec, counted []*revisor.BlockSpec,
+) func() {
+	field := kindField(kind)
+
+	optional := "?"
+
+	for _, s := range counted {
+		if (s.Count != nil && *s.Count > 0) ||
+			(s.MinCount != nil && *s.MinCount > 0) {
+			optional = ""
+		}
+	}
+
+	if len(specs) == 0 {
+		g.line("  %s?: never[];", field)
+
+		return func() {}
+	}
+
+	union := g.names.unique(parent + kindName(kind))
+
+	g.line("  %s%s: %s[];", field, optional, union)
+
+	return func() {
+		members := make([]string, len(specs))
+
+		for i, s := range specs {
+			members[i] = g.names.unique(
+				union + identifier(signatureParts(s.Declares)...))
+		}
+
+		g.line("")
+		g.line("export type %s = %s;", union, strings.Join(members, " | "))
+
+		for i, s := range specs {
+			g.blockType(members[i], s)
+		}
+	}
+}
+
+func (g *tsGenerator) blockType(name string, spec *revisor.BlockSpec) {
+	g.line("")
+	g.comment("", spec.Deprecated, spec.Name, spec.Description)
+	g.line("export interface %s {", name)
+	g.line("  id?: string;")
+
+	attributes := conditionalConstraints(spec.Attributes, spec.Conditional,
+		func(bs *revisor.BlockSpec) revisor.ConstraintMap {
+			return bs.Attributes
+		})
+
+	sig := map[string]string{
+		"type": spec.Declares.Type,
+		"rel":  spec.Declares.Rel,
+		"role": spec.Declares.Role,
+	}
+
+	for _, k := range blockAttributes {
+		if v := sig[k]; v != "" {
+			g.line("  %s: %s;", k, strconv.Quote(v))
+
+			continue
+		}
+
+		c, declared := attributes.Constraints[k]
+		if !declared {
+			continue
+		}
+
+		g.property("  ", k, c, c.AllowEmpty)
+	}
+
+	data := conditionalConstraints(spec.Data, spec.Conditional,
+		func(bs *revisor.BlockSpec) revisor.ConstraintMap {
+			return bs.Data
+		})
+
+	if len(data.Keys) > 0 {
+		optional := "?"
+
+		for _, k := range data.Keys {
+			if !data.Constraints[k].Optional {
+				optional = ""
+			}
+		}
+
+		g.line("  data%s: {", optional)
+
+		for _, k := range data.Keys {
+			c := data.Constraints[k]
+
+			g.property("    ", k, c, c.Optional)
+		}
+
+		g.line("  };")
+	}
+
+	var children []func()
+
+	for _, kind := range blockKinds {
+		children = append(children, g.blockList(name, kind,
+			conditionalBlocks(spec, kind), spec.Blocks(kind)))
+	}
+
+	g.line("}")
+
+	for _, fn := range children {
+		fn()
+	}
+}
+
+func (g *tsGenerator) property(
+	indent string, name string, c revisor.StringConstraint, optional bool,
+) {
+	var format string
+
+	if c.Format != revisor.StringFormatNone {
+		format = "Must be " + g.formats.Describe(c.Format) + "."
+	}
+
+	g.comment(indent, c.Deprecated, c.Name, c.Description, format)
+
+	mark := ""
+	if optional {
+		mark = "?"
+	}
+
+	g.line("%s%s%s: %s;", indent, propertyName(name), mark, g.valueType(c))
+}
+
+func (g *tsGenerator) valueType(c revisor.StringConstraint) string {
+	var literals []string
+
+	switch {
+	case c.Const != nil:
+		literals = append(literals, strconv.Quote(*c.Const))
+	case len(c.Enum) > 0:
+		for _, v := range c.Enum {
+			literals = append(literals, strconv.Quote(v))
+		}
+	case c.EnumRef != "" && g.enums[c.EnumRef] != "":
+		literals = append(literals, g.enums[c.EnumRef])
+	default:
+		return "string"
+	}
+
+	if c.AllowEmpty {
+		literals = append(literals, `""`)
+	}
+
+	return strings.Join(literals, " | ")
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func propertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+
+	return strconv.Quote(name)
+}
+
+func kindField(kind revisor.BlockKind) string {
+	switch kind {
+	case revisor.BlockKindLink:
+		return "links"
+	case revisor.BlockKindMeta:
+		return "meta"
+	case revisor.BlockKindContent:
+		return "content"
+	}
+
+	return string(kind)
+}
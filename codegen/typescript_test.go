@@ -0,0 +1,49 @@
+package codegen_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/codegen"
+)
+
+func TestTypeScript(t *testing.T) {
+	testGolden(t, ".ts", codegen.TypeScript)
+}
+
+func TestTypeScriptCustomFormat(t *testing.T) {
+	v, err := revisor.NewValidator(revisor.ConstraintSet{
+		Version: 1,
+		Name:    "custom-format",
+		Documents: []revisor.DocumentConstraint{
+			{
+				Declares: "test/article",
+				Attributes: revisor.MakeConstraintMap(
+					map[string]revisor.StringConstraint{
+						"uri": {Format: "tt-article-id"},
+					},
+				),
+			},
+		},
+	})
+	mustf(t, err, "create validator")
+
+	v, err = v.WithFormats(revisor.CustomFormat{
+		Name:        "tt-article-id",
+		Description: "an article ID",
+		Validate:    func(_ string) error { return nil },
+	})
+	mustf(t, err, "add custom formats")
+
+	var buf bytes.Buffer
+
+	err = codegen.TypeScript(&buf, v)
+	mustf(t, err, "generate code")
+
+	if !strings.Contains(buf.String(), "Must be an article ID.") {
+		t.Fatalf("expected the custom format to be described, got:\n%s",
+			buf.String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"slices"
 	"strconv"
 	"strings"
@@ -14,98 +15,192 @@ type ColourFormat string
 const (
 	ColourUnknown ColourFormat = ""
 	ColourHex     ColourFormat = "hex"
-	ColourRGB     ColourFormat = "rgb"
-	ColourRGBA    ColourFormat = "rgba"
+	// ColourShortHex is the three digit hex format "#rgb".
+	ColourShortHex ColourFormat = "short-hex"
+	// ColourHexAlpha is the eight digit hex format "#rrggbbaa".
+	ColourHexAlpha ColourFormat = "hex-alpha"
+	ColourRGB      ColourFormat = "rgb"
+	ColourRGBA     ColourFormat = "rgba"
+	ColourHSL      ColourFormat = "hsl"
+	ColourHSLA     ColourFormat = "hsla"
+	// ColourNamed is a CSS named colour, f.ex. "rebeccapurple".
+	ColourNamed ColourFormat = "named"
 )
 
+// rgbaColour is a parsed colour, the alpha channel is stored with the same
+// precision as the colour channels.
+type rgbaColour struct {
+	R, G, B, A uint8
+}
+
 type cFormatSpec struct {
-	Format   ColourFormat
-	Prefix   string
-	Validate func(spec cFormatSpec, code string) error
+	Format ColourFormat
+	Prefix string
+	// Length is the required length of the code after the prefix, zero
+	// if the length doesn't identify the format.
+	Length int
+	Parse  func(spec cFormatSpec, code string) (rgbaColour, error)
 }
 
 var (
 	defaultColourFormats = []ColourFormat{ColourRGB, ColourRGBA}
 	colourComponents     = []string{"r", "g", "b", "alpha"}
+	hslComponents        = []string{"hue", "saturation", "lightness", "alpha"}
 	colourFormats        = []cFormatSpec{
 		{
-			Format:   ColourHex,
-			Prefix:   "#",
-			Validate: parseHex,
+			Format: ColourShortHex,
+			Prefix: "#",
+			Length: 3,
+			Parse:  parseHex,
+		},
+		{
+			Format: ColourHexAlpha,
+			Prefix: "#",
+			Length: 8,
+			Parse:  parseHex,
+		},
+		{
+			Format: ColourHex,
+			Prefix: "#",
+			Parse:  parseHex,
+		},
+		{
+			Format: ColourRGBA,
+			Prefix: "rgba",
+			Parse:  parseRGBA,
+		},
+		{
+			Format: ColourRGB,
+			Prefix: "rgb",
+			Parse:  parseRGBA,
 		},
 		{
-			Format:   ColourRGBA,
-			Prefix:   "rgba",
-			Validate: parseRGBA,
+			Format: ColourHSLA,
+			Prefix: "hsla",
+			Parse:  parseHSLA,
 		},
 		{
-			Format:   ColourRGB,
-			Prefix:   "rgb",
-			Validate: parseRGBA,
+			Format: ColourHSL,
+			Prefix: "hsl",
+			Parse:  parseHSLA,
 		},
 	}
 )
 
 func validateColour(value string, formats []ColourFormat) error {
+	_, _, err := parseColour(value, formats)
+
+	return err
+}
+
+// parseColour parses a colour in one of the given formats, or in one of the
+// default formats if no formats are given.
+func parseColour(value string, formats []ColourFormat) (ColourFormat, rgbaColour, error) {
 	var (
 		spec cFormatSpec
 		code string
 	)
 
+	if len(formats) == 0 {
+		formats = defaultColourFormats
+	}
+
 	for _, s := range colourFormats {
 		after, ok := strings.CutPrefix(value, s.Prefix)
 		if !ok {
 			continue
 		}
 
+		// Formats that are identified by their length are only
+		// considered if they are allowed, so that f.ex. "#rgb" gets a
+		// length error when only "hex" is allowed.
+		if s.Length != 0 && (len(after) != s.Length ||
+			!slices.Contains(formats, s.Format)) {
+			continue
+		}
+
 		spec = s
 		code = after
 
 		break
 	}
 
-	if len(formats) == 0 {
-		formats = defaultColourFormats
+	if spec.Format == ColourUnknown {
+		_, ok := namedColours[strings.ToLower(value)]
+		if ok {
+			spec = cFormatSpec{
+				Format: ColourNamed,
+				Parse:  parseNamed,
+			}
+			code = value
+		}
 	}
 
 	if spec.Format == ColourUnknown || !slices.Contains(formats, spec.Format) {
 		if len(formats) == 1 {
-			return fmt.Errorf("expected a colour in the format %q",
-				formats[0])
+			return ColourUnknown, rgbaColour{}, fmt.Errorf(
+				"expected a colour in the format %q", formats[0])
 		}
 
-		return fmt.Errorf("expected a colour in one of the formats %s",
+		return ColourUnknown, rgbaColour{}, fmt.Errorf(
+			"expected a colour in one of the formats %s",
 			quotedSlice(formats))
 	}
 
-	return spec.Validate(spec, code)
+	c, err := spec.Parse(spec, code)
+	if err != nil {
+		return ColourUnknown, rgbaColour{}, err
+	}
+
+	return spec.Format, c, nil
 }
 
 const hexColourLength = 6
 
-func parseHex(_ cFormatSpec, code string) error {
-	if len(code) != hexColourLength {
-		return fmt.Errorf("code length: expected %d characters, got %d",
-			hexColourLength, len(code))
+func parseHex(spec cFormatSpec, code string) (rgbaColour, error) {
+	length := spec.Length
+	if length == 0 {
+		length = hexColourLength
+	}
+
+	if len(code) != length {
+		return rgbaColour{}, fmt.Errorf(
+			"code length: expected %d characters, got %d",
+			length, len(code))
+	}
+
+	// Expand "rgb" to "rrggbb".
+	if length == 3 {
+		code = string([]byte{
+			code[0], code[0], code[1], code[1], code[2], code[2],
+		})
 	}
 
-	_, err := hex.DecodeString(code)
+	b, err := hex.DecodeString(code)
 	if err != nil {
-		return fmt.Errorf("invalid hex code: %w", err)
+		return rgbaColour{}, fmt.Errorf("invalid hex code: %w", err)
 	}
 
-	return nil
+	c := rgbaColour{R: b[0], G: b[1], B: b[2], A: 255}
+
+	if len(b) == 4 {
+		c.A = b[3]
+	}
+
+	return c, nil
 }
 
-func parseRGBA(spec cFormatSpec, code string) error {
+// parseComponents parses the comma separated components of a "rgb(...)"
+// style colour.
+func parseComponents(spec cFormatSpec, code string) ([]string, error) {
 	rest, ok := strings.CutPrefix(code, "(")
 	if !ok {
-		return errors.New("missing starting '('")
+		return nil, errors.New("missing starting '('")
 	}
 
 	rest, ok = strings.CutSuffix(rest, ")")
 	if !ok {
-		return errors.New("missing closing ')'")
+		return nil, errors.New("missing closing ')'")
 	}
 
 	numberStrings := strings.Split(rest, ",")
@@ -113,43 +208,301 @@ func parseRGBA(spec cFormatSpec, code string) error {
 
 	//nolint: exhaustive
 	switch spec.Format {
-	case ColourRGB:
+	case ColourRGB, ColourHSL:
 		if components != 3 {
-			return fmt.Errorf("expected three components in a rgb() value, got %d", components)
+			return nil, fmt.Errorf(
+				"expected three components in a %s() value, got %d",
+				spec.Format, components)
 		}
-	case ColourRGBA:
+	case ColourRGBA, ColourHSLA:
 		if components != 4 {
-			return fmt.Errorf("expected four components in a rgba() value, got %d", components)
-		}
-
-		n, err := strconv.ParseFloat(strings.TrimSpace(numberStrings[3]), 64)
-		if err != nil {
-			return fmt.Errorf("invalid alpha value: %w", err)
-		}
-
-		if n < 0 || n > 1 {
-			return fmt.Errorf("%q out of range", colourComponents[3])
+			return nil, fmt.Errorf(
+				"expected four components in a %s() value, got %d",
+				spec.Format, components)
 		}
 	default:
-		return fmt.Errorf(
-			"configuration error: cannot parse %q with parseRGBA()",
+		return nil, fmt.Errorf(
+			"configuration error: cannot parse %q as components",
 			spec.Format,
 		)
 	}
 
+	for i := range numberStrings {
+		numberStrings[i] = strings.TrimSpace(numberStrings[i])
+	}
+
+	return numberStrings, nil
+}
+
+// parseNumber parses a finite floating point number, ParseFloat also accepts
+// "NaN" and "Inf".
+func parseNumber(value string) (float64, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", value)
+	}
+
+	return n, nil
+}
+
+// parseAlpha parses an alpha value in the range 0-1.
+func parseAlpha(value string) (uint8, error) {
+	n, err := parseNumber(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid alpha value: %w", err)
+	}
+
+	if n < 0 || n > 1 {
+		return 0, fmt.Errorf("%q out of range", colourComponents[3])
+	}
+
+	return uint8(math.Round(n * 255)), nil
+}
+
+func parseRGBA(spec cFormatSpec, code string) (rgbaColour, error) {
+	numberStrings, err := parseComponents(spec, code)
+	if err != nil {
+		return rgbaColour{}, err
+	}
+
+	c := rgbaColour{A: 255}
+
+	if len(numberStrings) == 4 {
+		c.A, err = parseAlpha(numberStrings[3])
+		if err != nil {
+			return rgbaColour{}, err
+		}
+	}
+
+	channels := []*uint8{&c.R, &c.G, &c.B}
+
 	for i, ns := range numberStrings[:3] {
-		n, err := strconv.Atoi(strings.TrimSpace(ns))
+		n, err := strconv.Atoi(ns)
 		if err != nil {
-			return fmt.Errorf("invalid %q value: %w",
+			return rgbaColour{}, fmt.Errorf("invalid %q value: %w",
 				colourComponents[i], err)
 		}
 
 		if n < 0 || n > 255 {
-			return fmt.Errorf("%q out of range", colourComponents[i])
+			return rgbaColour{}, fmt.Errorf("%q out of range", colourComponents[i])
 		}
+
+		*channels[i] = uint8(n)
+	}
+
+	return c, nil
+}
+
+func parseHSLA(spec cFormatSpec, code string) (rgbaColour, error) {
+	numberStrings, err := parseComponents(spec, code)
+	if err != nil {
+		return rgbaColour{}, err
+	}
+
+	alpha := uint8(255)
+
+	if len(numberStrings) == 4 {
+		alpha, err = parseAlpha(numberStrings[3])
+		if err != nil {
+			return rgbaColour{}, err
+		}
+	}
+
+	hue, err := parseNumber(numberStrings[0])
+	if err != nil {
+		return rgbaColour{}, fmt.Errorf("invalid %q value: %w",
+			hslComponents[0], err)
+	}
+
+	var percentages [2]float64
+
+	for i, ns := range numberStrings[1:3] {
+		name := hslComponents[i+1]
+
+		number, ok := strings.CutSuffix(ns, "%")
+		if !ok {
+			return rgbaColour{}, fmt.Errorf("%q must be a percentage", name)
+		}
+
+		n, err := parseNumber(number)
+		if err != nil {
+			return rgbaColour{}, fmt.Errorf("invalid %q value: %w", name, err)
+		}
+
+		if n < 0 || n > 100 {
+			return rgbaColour{}, fmt.Errorf("%q out of range", name)
+		}
+
+		percentages[i] = n / 100
+	}
+
+	c := hslToRGB(hue, percentages[0], percentages[1])
+
+	c.A = alpha
+
+	return c, nil
+}
+
+// hslToRGB converts a HSL colour to RGB, the hue is given in degrees and
+// saturation and lightness in the range 0-1.
+func hslToRGB(hue, saturation, lightness float64) rgbaColour {
+	hue = math.Mod(hue, 360)
+	if hue < 0 {
+		hue += 360
+	}
+
+	channel := func(n float64) uint8 {
+		k := math.Mod(n+hue/30, 12)
+		a := saturation * min(lightness, 1-lightness)
+		v := lightness - a*max(-1, min(k-3, 9-k, 1))
+
+		return uint8(math.Round(v * 255))
+	}
+
+	return rgbaColour{R: channel(0), G: channel(8), B: channel(4), A: 255}
+}
+
+// rgbToHSL converts a colour to HSL with the hue in degrees and saturation and
+// lightness in percent.
+func rgbToHSL(c rgbaColour) (float64, float64, float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+
+	high := max(r, g, b)
+	low := min(r, g, b)
+	lightness := (high + low) / 2
+
+	if high == low {
+		return 0, 0, lightness * 100
+	}
+
+	d := high - low
+
+	saturation := d / (1 - math.Abs(2*lightness-1))
+
+	var hue float64
+
+	switch high {
+	case r:
+		hue = math.Mod((g-b)/d, 6)
+	case g:
+		hue = (b-r)/d + 2
+	default:
+		hue = (r-g)/d + 4
+	}
+
+	hue *= 60
+	if hue < 0 {
+		hue += 360
+	}
+
+	return hue, saturation * 100, lightness * 100
+}
+
+func parseNamed(_ cFormatSpec, code string) (rgbaColour, error) {
+	c, ok := namedColours[strings.ToLower(code)]
+	if !ok {
+		return rgbaColour{}, fmt.Errorf("unknown colour name %q", code)
+	}
+
+	return c, nil
+}
+
+// formatColour formats the colour in the given format. Returns false if the
+// colour can't be represented in the format.
+func formatColour(c rgbaColour, format ColourFormat) (string, bool) {
+	opaque := c.A == 255
+
+	switch format {
+	case ColourHex:
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B), opaque
+	case ColourShortHex:
+		if c.R%17 != 0 || c.G%17 != 0 || c.B%17 != 0 {
+			return "", false
+		}
+
+		return fmt.Sprintf("#%x%x%x", c.R/17, c.G/17, c.B/17), opaque
+	case ColourHexAlpha:
+		return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A), true
+	case ColourRGB:
+		return fmt.Sprintf("rgb(%d, %d, %d)", c.R, c.G, c.B), opaque
+	case ColourRGBA:
+		return fmt.Sprintf("rgba(%d, %d, %d, %s)",
+			c.R, c.G, c.B, formatAlpha(c.A)), true
+	case ColourHSL, ColourHSLA:
+		h, s, l := rgbToHSL(c)
+
+		if format == ColourHSL {
+			return fmt.Sprintf("hsl(%s, %s%%, %s%%)",
+				formatHSLNumber(h), formatHSLNumber(s),
+				formatHSLNumber(l)), opaque
+		}
+
+		return fmt.Sprintf("hsla(%s, %s%%, %s%%, %s)",
+			formatHSLNumber(h), formatHSLNumber(s),
+			formatHSLNumber(l), formatAlpha(c.A)), true
+	case ColourNamed:
+		name, ok := colourNames[c]
+
+		return name, ok
+	case ColourUnknown:
+	}
+
+	return "", false
+}
+
+// formatAlpha formats the alpha channel with as few decimals as possible.
+func formatAlpha(alpha uint8) string {
+	for prec := 0; prec < 3; prec++ {
+		s := strconv.FormatFloat(float64(alpha)/255, 'f', prec, 64)
+
+		n, _ := strconv.ParseFloat(s, 64)
+		if uint8(math.Round(n*255)) == alpha {
+			return s
+		}
+	}
+
+	return strconv.FormatFloat(float64(alpha)/255, 'f', 3, 64)
+}
+
+func formatHSLNumber(n float64) string {
+	return strconv.FormatFloat(math.Round(n*10)/10, 'f', -1, 64)
+}
+
+// normaliseColour converts a valid colour to the target format. Returns false
+// if the value already is in the target format, if the target format isn't
+// one of the allowed formats, or if the conversion would lose information.
+func normaliseColour(
+	value string, formats []ColourFormat, target ColourFormat,
+) (string, bool) {
+	if len(formats) == 0 {
+		formats = defaultColourFormats
+	}
+
+	if target == ColourUnknown || !slices.Contains(formats, target) {
+		return "", false
+	}
+
+	_, c, err := parseColour(value, formats)
+	if err != nil {
+		return "", false
+	}
+
+	out, ok := formatColour(c, target)
+	if !ok || out == value {
+		return "", false
+	}
+
+	// Verify that the conversion is lossless.
+	_, check, err := parseColour(out, formats)
+	if err != nil || check != c {
+		return "", false
 	}
 
-	return nil
+	return out, true
 }
 
 func quotedSlice[T any](s []T) string {
@@ -0,0 +1,171 @@
+package revisor
+
+// namedColours contains the CSS named colours.
+var namedColours = map[string]rgbaColour{
+	"aliceblue":            {R: 0xf0, G: 0xf8, B: 0xff, A: 0xff},
+	"antiquewhite":         {R: 0xfa, G: 0xeb, B: 0xd7, A: 0xff},
+	"aqua":                 {R: 0x00, G: 0xff, B: 0xff, A: 0xff},
+	"aquamarine":           {R: 0x7f, G: 0xff, B: 0xd4, A: 0xff},
+	"azure":                {R: 0xf0, G: 0xff, B: 0xff, A: 0xff},
+	"beige":                {R: 0xf5, G: 0xf5, B: 0xdc, A: 0xff},
+	"bisque":               {R: 0xff, G: 0xe4, B: 0xc4, A: 0xff},
+	"black":                {R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+	"blanchedalmond":       {R: 0xff, G: 0xeb, B: 0xcd, A: 0xff},
+	"blue":                 {R: 0x00, G: 0x00, B: 0xff, A: 0xff},
+	"blueviolet":           {R: 0x8a, G: 0x2b, B: 0xe2, A: 0xff},
+	"brown":                {R: 0xa5, G: 0x2a, B: 0x2a, A: 0xff},
+	"burlywood":            {R: 0xde, G: 0xb8, B: 0x87, A: 0xff},
+	"cadetblue":            {R: 0x5f, G: 0x9e, B: 0xa0, A: 0xff},
+	"chartreuse":           {R: 0x7f, G: 0xff, B: 0x00, A: 0xff},
+	"chocolate":            {R: 0xd2, G: 0x69, B: 0x1e, A: 0xff},
+	"coral":                {R: 0xff, G: 0x7f, B: 0x50, A: 0xff},
+	"cornflowerblue":       {R: 0x64, G: 0x95, B: 0xed, A: 0xff},
+	"cornsilk":             {R: 0xff, G: 0xf8, B: 0xdc, A: 0xff},
+	"crimson":              {R: 0xdc, G: 0x14, B: 0x3c, A: 0xff},
+	"cyan":                 {R: 0x00, G: 0xff, B: 0xff, A: 0xff},
+	"darkblue":             {R: 0x00, G: 0x00, B: 0x8b, A: 0xff},
+	"darkcyan":             {R: 0x00, G: 0x8b, B: 0x8b, A: 0xff},
+	"darkgoldenrod":        {R: 0xb8, G: 0x86, B: 0x0b, A: 0xff},
+	"darkgray":             {R: 0xa9, G: 0xa9, B: 0xa9, A: 0xff},
+	"darkgreen":            {R: 0x00, G: 0x64, B: 0x00, A: 0xff},
+	"darkgrey":             {R: 0xa9, G: 0xa9, B: 0xa9, A: 0xff},
+	"darkkhaki":            {R: 0xbd, G: 0xb7, B: 0x6b, A: 0xff},
+	"darkmagenta":          {R: 0x8b, G: 0x00, B: 0x8b, A: 0xff},
+	"darkolivegreen":       {R: 0x55, G: 0x6b, B: 0x2f, A: 0xff},
+	"darkorange":           {R: 0xff, G: 0x8c, B: 0x00, A: 0xff},
+	"darkorchid":           {R: 0x99, G: 0x32, B: 0xcc, A: 0xff},
+	"darkred":              {R: 0x8b, G: 0x00, B: 0x00, A: 0xff},
+	"darksalmon":           {R: 0xe9, G: 0x96, B: 0x7a, A: 0xff},
+	"darkseagreen":         {R: 0x8f, G: 0xbc, B: 0x8f, A: 0xff},
+	"darkslateblue":        {R: 0x48, G: 0x3d, B: 0x8b, A: 0xff},
+	"darkslategray":        {R: 0x2f, G: 0x4f, B: 0x4f, A: 0xff},
+	"darkslategrey":        {R: 0x2f, G: 0x4f, B: 0x4f, A: 0xff},
+	"darkturquoise":        {R: 0x00, G: 0xce, B: 0xd1, A: 0xff},
+	"darkviolet":           {R: 0x94, G: 0x00, B: 0xd3, A: 0xff},
+	"deeppink":             {R: 0xff, G: 0x14, B: 0x93, A: 0xff},
+	"deepskyblue":          {R: 0x00, G: 0xbf, B: 0xff, A: 0xff},
+	"dimgray":              {R: 0x69, G: 0x69, B: 0x69, A: 0xff},
+	"dimgrey":              {R: 0x69, G: 0x69, B: 0x69, A: 0xff},
+	"dodgerblue":           {R: 0x1e, G: 0x90, B: 0xff, A: 0xff},
+	"firebrick":            {R: 0xb2, G: 0x22, B: 0x22, A: 0xff},
+	"floralwhite":          {R: 0xff, G: 0xfa, B: 0xf0, A: 0xff},
+	"forestgreen":          {R: 0x22, G: 0x8b, B: 0x22, A: 0xff},
+	"fuchsia":              {R: 0xff, G: 0x00, B: 0xff, A: 0xff},
+	"gainsboro":            {R: 0xdc, G: 0xdc, B: 0xdc, A: 0xff},
+	"ghostwhite":           {R: 0xf8, G: 0xf8, B: 0xff, A: 0xff},
+	"gold":                 {R: 0xff, G: 0xd7, B: 0x00, A: 0xff},
+	"goldenrod":            {R: 0xda, G: 0xa5, B: 0x20, A: 0xff},
+	"gray":                 {R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+	"green":                {R: 0x00, G: 0x80, B: 0x00, A: 0xff},
+	"greenyellow":          {R: 0xad, G: 0xff, B: 0x2f, A: 0xff},
+	"grey":                 {R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+	"honeydew":             {R: 0xf0, G: 0xff, B: 0xf0, A: 0xff},
+	"hotpink":              {R: 0xff, G: 0x69, B: 0xb4, A: 0xff},
+	"indianred":            {R: 0xcd, G: 0x5c, B: 0x5c, A: 0xff},
+	"indigo":               {R: 0x4b, G: 0x00, B: 0x82, A: 0xff},
+	"ivory":                {R: 0xff, G: 0xff, B: 0xf0, A: 0xff},
+	"khaki":                {R: 0xf0, G: 0xe6, B: 0x8c, A: 0xff},
+	"lavender":             {R: 0xe6, G: 0xe6, B: 0xfa, A: 0xff},
+	"lavenderblush":        {R: 0xff, G: 0xf0, B: 0xf5, A: 0xff},
+	"lawngreen":            {R: 0x7c, G: 0xfc, B: 0x00, A: 0xff},
+	"lemonchiffon":         {R: 0xff, G: 0xfa, B: 0xcd, A: 0xff},
+	"lightblue":            {R: 0xad, G: 0xd8, B: 0xe6, A: 0xff},
+	"lightcoral":           {R: 0xf0, G: 0x80, B: 0x80, A: 0xff},
+	"lightcyan":            {R: 0xe0, G: 0xff, B: 0xff, A: 0xff},
+	"lightgoldenrodyellow": {R: 0xfa, G: 0xfa, B: 0xd2, A: 0xff},
+	"lightgray":            {R: 0xd3, G: 0xd3, B: 0xd3, A: 0xff},
+	"lightgreen":           {R: 0x90, G: 0xee, B: 0x90, A: 0xff},
+	"lightgrey":            {R: 0xd3, G: 0xd3, B: 0xd3, A: 0xff},
+	"lightpink":            {R: 0xff, G: 0xb6, B: 0xc1, A: 0xff},
+	"lightsalmon":          {R: 0xff, G: 0xa0, B: 0x7a, A: 0xff},
+	"lightseagreen":        {R: 0x20, G: 0xb2, B: 0xaa, A: 0xff},
+	"lightskyblue":         {R: 0x87, G: 0xce, B: 0xfa, A: 0xff},
+	"lightslategray":       {R: 0x77, G: 0x88, B: 0x99, A: 0xff},
+	"lightslategrey":       {R: 0x77, G: 0x88, B: 0x99, A: 0xff},
+	"lightsteelblue":       {R: 0xb0, G: 0xc4, B: 0xde, A: 0xff},
+	"lightyellow":          {R: 0xff, G: 0xff, B: 0xe0, A: 0xff},
+	"lime":                 {R: 0x00, G: 0xff, B: 0x00, A: 0xff},
+	"limegreen":            {R: 0x32, G: 0xcd, B: 0x32, A: 0xff},
+	"linen":                {R: 0xfa, G: 0xf0, B: 0xe6, A: 0xff},
+	"magenta":              {R: 0xff, G: 0x00, B: 0xff, A: 0xff},
+	"maroon":               {R: 0x80, G: 0x00, B: 0x00, A: 0xff},
+	"mediumaquamarine":     {R: 0x66, G: 0xcd, B: 0xaa, A: 0xff},
+	"mediumblue":           {R: 0x00, G: 0x00, B: 0xcd, A: 0xff},
+	"mediumorchid":         {R: 0xba, G: 0x55, B: 0xd3, A: 0xff},
+	"mediumpurple":         {R: 0x93, G: 0x70, B: 0xdb, A: 0xff},
+	"mediumseagreen":       {R: 0x3c, G: 0xb3, B: 0x71, A: 0xff},
+	"mediumslateblue":      {R: 0x7b, G: 0x68, B: 0xee, A: 0xff},
+	"mediumspringgreen":    {R: 0x00, G: 0xfa, B: 0x9a, A: 0xff},
+	"mediumturquoise":      {R: 0x48, G: 0xd1, B: 0xcc, A: 0xff},
+	"mediumvioletred":      {R: 0xc7, G: 0x15, B: 0x85, A: 0xff},
+	"midnightblue":         {R: 0x19, G: 0x19, B: 0x70, A: 0xff},
+	"mintcream":            {R: 0xf5, G: 0xff, B: 0xfa, A: 0xff},
+	"mistyrose":            {R: 0xff, G: 0xe4, B: 0xe1, A: 0xff},
+	"moccasin":             {R: 0xff, G: 0xe4, B: 0xb5, A: 0xff},
+	"navajowhite":          {R: 0xff, G: 0xde, B: 0xad, A: 0xff},
+	"navy":                 {R: 0x00, G: 0x00, B: 0x80, A: 0xff},
+	"oldlace":              {R: 0xfd, G: 0xf5, B: 0xe6, A: 0xff},
+	"olive":                {R: 0x80, G: 0x80, B: 0x00, A: 0xff},
+	"olivedrab":            {R: 0x6b, G: 0x8e, B: 0x23, A: 0xff},
+	"orange":               {R: 0xff, G: 0xa5, B: 0x00, A: 0xff},
+	"orangered":            {R: 0xff, G: 0x45, B: 0x00, A: 0xff},
+	"orchid":               {R: 0xda, G: 0x70, B: 0xd6, A: 0xff},
+	"palegoldenrod":        {R: 0xee, G: 0xe8, B: 0xaa, A: 0xff},
+	"palegreen":            {R: 0x98, G: 0xfb, B: 0x98, A: 0xff},
+	"paleturquoise":        {R: 0xaf, G: 0xee, B: 0xee, A: 0xff},
+	"palevioletred":        {R: 0xdb, G: 0x70, B: 0x93, A: 0xff},
+	"papayawhip":           {R: 0xff, G: 0xef, B: 0xd5, A: 0xff},
+	"peachpuff":            {R: 0xff, G: 0xda, B: 0xb9, A: 0xff},
+	"peru":                 {R: 0xcd, G: 0x85, B: 0x3f, A: 0xff},
+	"pink":                 {R: 0xff, G: 0xc0, B: 0xcb, A: 0xff},
+	"plum":                 {R: 0xdd, G: 0xa0, B: 0xdd, A: 0xff},
+	"powderblue":           {R: 0xb0, G: 0xe0, B: 0xe6, A: 0xff},
+	"purple":               {R: 0x80, G: 0x00, B: 0x80, A: 0xff},
+	"rebeccapurple":        {R: 0x66, G: 0x33, B: 0x99, A: 0xff},
+	"red":                  {R: 0xff, G: 0x00, B: 0x00, A: 0xff},
+	"rosybrown":            {R: 0xbc, G: 0x8f, B: 0x8f, A: 0xff},
+	"royalblue":            {R: 0x41, G: 0x69, B: 0xe1, A: 0xff},
+	"saddlebrown":          {R: 0x8b, G: 0x45, B: 0x13, A: 0xff},
+	"salmon":               {R: 0xfa, G: 0x80, B: 0x72, A: 0xff},
+	"sandybrown":           {R: 0xf4, G: 0xa4, B: 0x60, A: 0xff},
+	"seagreen":             {R: 0x2e, G: 0x8b, B: 0x57, A: 0xff},
+	"seashell":             {R: 0xff, G: 0xf5, B: 0xee, A: 0xff},
+	"sienna":               {R: 0xa0, G: 0x52, B: 0x2d, A: 0xff},
+	"silver":               {R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff},
+	"skyblue":              {R: 0x87, G: 0xce, B: 0xeb, A: 0xff},
+	"slateblue":            {R: 0x6a, G: 0x5a, B: 0xcd, A: 0xff},
+	"slategray":            {R: 0x70, G: 0x80, B: 0x90, A: 0xff},
+	"slategrey":            {R: 0x70, G: 0x80, B: 0x90, A: 0xff},
+	"snow":                 {R: 0xff, G: 0xfa, B: 0xfa, A: 0xff},
+	"springgreen":          {R: 0x00, G: 0xff, B: 0x7f, A: 0xff},
+	"steelblue":            {R: 0x46, G: 0x82, B: 0xb4, A: 0xff},
+	"tan":                  {R: 0xd2, G: 0xb4, B: 0x8c, A: 0xff},
+	"teal":                 {R: 0x00, G: 0x80, B: 0x80, A: 0xff},
+	"thistle":              {R: 0xd8, G: 0xbf, B: 0xd8, A: 0xff},
+	"tomato":               {R: 0xff, G: 0x63, B: 0x47, A: 0xff},
+	"turquoise":            {R: 0x40, G: 0xe0, B: 0xd0, A: 0xff},
+	"violet":               {R: 0xee, G: 0x82, B: 0xee, A: 0xff},
+	"wheat":                {R: 0xf5, G: 0xde, B: 0xb3, A: 0xff},
+	"white":                {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	"whitesmoke":           {R: 0xf5, G: 0xf5, B: 0xf5, A: 0xff},
+	"yellow":               {R: 0xff, G: 0xff, B: 0x00, A: 0xff},
+	"yellowgreen":          {R: 0x9a, G: 0xcd, B: 0x32, A: 0xff},
+	"transparent":          {},
+}
+
+// colourNames maps colours to their CSS name, the alphabetically first name
+// is used for colours that have more than one name, f.ex. "aqua" and "cyan".
+var colourNames = func() map[rgbaColour]string {
+	m := make(map[rgbaColour]string, len(namedColours))
+
+	for name, c := range namedColours {
+		current, ok := m[c]
+		if ok && current < name {
+			continue
+		}
+
+		m[c] = name
+	}
+
+	return m
+}()
@@ -0,0 +1,56 @@
+package revisor
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// validateEmail validates a bare email address, f.ex. "someone@example.com".
+func validateEmail(value string) error {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return errors.New(strings.TrimPrefix(err.Error(), "mail: "))
+	}
+
+	if addr.Name != "" || addr.Address != value {
+		return errors.New("expected a bare address without a name or quoting")
+	}
+
+	return nil
+}
+
+var e164Exp = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// validateE164 validates a E.164 phone number, f.ex. "+46701234567".
+func validateE164(value string) error {
+	if !e164Exp.MatchString(value) {
+		return errors.New(`expected "+" followed by a country code and at most 15 digits`)
+	}
+
+	return nil
+}
+
+// validateMIMEType validates a MIME type, f.ex. "image/jpeg", against the
+// allowed MIME types of the constraint.
+func validateMIMEType(value string, sc *StringConstraint) error {
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return errors.New(strings.TrimPrefix(err.Error(), "mime: "))
+	}
+
+	// ParseMediaType also accepts content dispositions without a
+	// subtype.
+	if !strings.Contains(mediaType, "/") {
+		return errors.New("expected a type and a subtype, f.ex. \"image/jpeg\"")
+	}
+
+	if !sc.MIMETypes.MatchOrEmpty(mediaType) {
+		return fmt.Errorf("the type %s", sc.MIMETypes.String())
+	}
+
+	return nil
+}
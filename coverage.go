@@ -0,0 +1,358 @@
+package revisor
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// specRef identifies the source of a constraint as the name of the constraint
+// set it was declared in and a JSON pointer to the constraint in that set.
+type specRef struct {
+	set  string
+	path string
+}
+
+func (r specRef) IsZero() bool {
+	return r.set == "" && r.path == ""
+}
+
+func (r specRef) child(segments ...string) specRef {
+	var s strings.Builder
+
+	s.WriteString(r.path)
+
+	for _, seg := range segments {
+		s.WriteByte('/')
+		s.WriteString(jsonPointerEscaper.Replace(seg))
+	}
+
+	return specRef{
+		set:  r.set,
+		path: s.String(),
+	}
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+var kindFields = map[BlockKind]string{
+	BlockKindLink:    "links",
+	BlockKindMeta:    "meta",
+	BlockKindContent: "content",
+}
+
+// setSpecRef sets the source reference for the block constraint and all its
+// attributes, data and child blocks.
+func (bc *BlockConstraint) setSpecRef(ref specRef) {
+	bc.spec = ref
+	bc.Attributes.spec = ref.child("attributes")
+	bc.Data.spec = ref.child("data")
+
+	for _, kind := range blockKinds {
+		for i, b := range bc.BlockConstraints(kind) {
+			b.setSpecRef(ref.child(kindFields[kind], strconv.Itoa(i)))
+		}
+	}
+}
+
+// setSpecRef sets the source reference for the document constraint and all its
+// attributes and blocks.
+func (dc *DocumentConstraint) setSpecRef(ref specRef) {
+	dc.spec = ref
+	dc.Attributes.spec = ref.child("attributes")
+
+	for _, kind := range blockKinds {
+		for i, b := range dc.BlockConstraints(kind) {
+			b.setSpecRef(ref.child(kindFields[kind], strconv.Itoa(i)))
+		}
+	}
+}
+
+// CoverageKind is the kind of constraint that a coverage entry describes.
+type CoverageKind string
+
+// The different kinds of constraints that coverage is collected for.
+const (
+	CoverageKindDocument  CoverageKind = "document"
+	CoverageKindBlock     CoverageKind = "block"
+	CoverageKindAttribute CoverageKind = "attribute"
+	CoverageKindData      CoverageKind = "data"
+	CoverageKindEnumValue CoverageKind = "enum value"
+)
+
+// CoverageEntry describes how many times a constraint was matched.
+type CoverageEntry struct {
+	// ConstraintSet is the name of the constraint set that the constraint
+	// was declared in.
+	ConstraintSet string `json:"constraintSet"`
+	// Path is a JSON pointer to the constraint in the constraint set.
+	Path        string       `json:"path"`
+	Kind        CoverageKind `json:"kind"`
+	Description string       `json:"description,omitempty"`
+	Count       int          `json:"count"`
+}
+
+// CoverageCollector records how many times the constraints of a validator
+// were matched. It's safe to use the same collector for concurrent
+// validations.
+type CoverageCollector struct {
+	m       sync.Mutex
+	sets    []string
+	entries map[specRef]*CoverageEntry
+	formats *FormatRegistry
+}
+
+// NewCoverageCollector creates a coverage collector that knows about all the
+// constraints of the validator, so that constraints that never were matched
+// will be reported with a zero count.
+func NewCoverageCollector(v *Validator) *CoverageCollector {
+	c := CoverageCollector{
+		entries: make(map[specRef]*CoverageEntry),
+		formats: v.formats,
+	}
+
+	for _, cs := range v.constraints {
+		c.sets = append(c.sets, cs.Name)
+	}
+
+	for _, d := range v.documents {
+		desc := d.Declares
+		if desc == "" {
+			desc = d.Match.RequirementsWithFormats(v.formats)
+		}
+
+		c.register(d.spec, CoverageKindDocument, desc)
+		c.registerMap(d.Attributes, CoverageKindAttribute)
+		c.registerBlocks(d)
+	}
+
+	for _, kind := range blockKinds {
+		for _, b := range v.blocks[kind] {
+			c.registerBlock(kind, b)
+		}
+	}
+
+	for name, e := range v.enums.enums {
+		for value, refs := range e.Sources {
+			for _, ref := range refs {
+				c.register(ref, CoverageKindEnumValue, fmt.Sprintf(
+					"%q in enum %q", value, name))
+			}
+		}
+	}
+
+	return &c
+}
+
+// WithCoverage collects constraint coverage during validation.
+func WithCoverage(
+	collector *CoverageCollector,
+) ValidationOptionFunc {
+	return func(vc *ValidationContext) {
+		vc.cov = collector
+	}
+}
+
+func (c *CoverageCollector) registerBlocks(source BlockConstraintSet) {
+	for _, kind := range blockKinds {
+		for _, b := range source.BlockConstraints(kind) {
+			c.registerBlock(kind, b)
+		}
+	}
+}
+
+func (c *CoverageCollector) registerBlock(kind BlockKind, b *BlockConstraint) {
+	var desc string
+
+	switch {
+	case b.Declares != nil:
+		desc = fmt.Sprintf("%s %s", kind.Description(1),
+			describeSignature(*b.Declares))
+	case len(b.Match.Keys) > 0:
+		desc = fmt.Sprintf("%s where %s", kind.Description(1),
+			b.Match.RequirementsWithFormats(c.formats))
+	default:
+		desc = kind.Description(1)
+	}
+
+	c.register(b.spec, CoverageKindBlock, desc)
+	c.registerMap(b.Attributes, CoverageKindAttribute)
+	c.registerMap(b.Data, CoverageKindData)
+	c.registerBlocks(b)
+}
+
+func (c *CoverageCollector) registerMap(cm ConstraintMap, kind CoverageKind) {
+	for _, k := range cm.Keys {
+		ref := cm.spec.child(k)
+
+		c.register(ref, kind, k)
+
+		for i, v := range cm.Constraints[k].Enum {
+			c.register(ref.child("enum", strconv.Itoa(i)),
+				CoverageKindEnumValue, fmt.Sprintf("%q for %s", v, k))
+		}
+	}
+}
+
+func (c *CoverageCollector) register(
+	ref specRef, kind CoverageKind, description string,
+) {
+	if ref.IsZero() {
+		return
+	}
+
+	_, exists := c.entries[ref]
+	if exists {
+		return
+	}
+
+	c.entries[ref] = &CoverageEntry{
+		ConstraintSet: ref.set,
+		Path:          ref.path,
+		Kind:          kind,
+		Description:   description,
+	}
+}
+
+// hit records a match for a constraint. Safe to call on a nil collector.
+func (c *CoverageCollector) hit(kind CoverageKind, refs ...specRef) {
+	if c == nil {
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for _, ref := range refs {
+		if ref.IsZero() {
+			continue
+		}
+
+		e, ok := c.entries[ref]
+		if !ok {
+			e = &CoverageEntry{
+				ConstraintSet: ref.set,
+				Path:          ref.path,
+				Kind:          kind,
+			}
+
+			c.entries[ref] = e
+		}
+
+		e.Count++
+	}
+}
+
+// hitValue records a match for a key in a constraint map, and for the enum
+// value of the constraint that the value matched, if any.
+func (c *CoverageCollector) hitValue(
+	kind CoverageKind, cm ConstraintMap, key string,
+	check StringConstraint, value string,
+) {
+	if c == nil {
+		return
+	}
+
+	ref := cm.spec.child(key)
+
+	c.hit(kind, ref)
+
+	idx := slices.Index(check.Enum, value)
+	if idx != -1 {
+		c.hit(CoverageKindEnumValue, ref.child("enum", strconv.Itoa(idx)))
+	}
+}
+
+// Entries returns the collected coverage, ordered by constraint set and the
+// position of the constraint in the set.
+func (c *CoverageCollector) Entries() []CoverageEntry {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	list := make([]CoverageEntry, 0, len(c.entries))
+
+	for _, e := range c.entries {
+		list = append(list, *e)
+	}
+
+	setIndex := func(name string) int {
+		idx := slices.Index(c.sets, name)
+		if idx == -1 {
+			return len(c.sets)
+		}
+
+		return idx
+	}
+
+	slices.SortFunc(list, func(a, b CoverageEntry) int {
+		if a.ConstraintSet != b.ConstraintSet {
+			ai, bi := setIndex(a.ConstraintSet), setIndex(b.ConstraintSet)
+			if ai != bi {
+				return ai - bi
+			}
+
+			return strings.Compare(a.ConstraintSet, b.ConstraintSet)
+		}
+
+		return comparePointers(a.Path, b.Path)
+	})
+
+	return list
+}
+
+// Unused returns the entries for the constraints that never were matched.
+func (c *CoverageCollector) Unused() []CoverageEntry {
+	var unused []CoverageEntry
+
+	for _, e := range c.Entries() {
+		if e.Count == 0 {
+			unused = append(unused, e)
+		}
+	}
+
+	return unused
+}
+
+// comparePointers compares JSON pointers segment by segment, comparing array
+// indexes numerically.
+func comparePointers(a, b string) int {
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+
+		if aErr == nil && bErr == nil {
+			return an - bn
+		}
+
+		return strings.Compare(as[i], bs[i])
+	}
+
+	return len(as) - len(bs)
+}
+
+func describeSignature(bs BlockSignature) string {
+	var parts []string
+
+	if bs.Type != "" {
+		parts = append(parts, fmt.Sprintf("type %q", bs.Type))
+	}
+
+	if bs.Rel != "" {
+		parts = append(parts, fmt.Sprintf("rel %q", bs.Rel))
+	}
+
+	if bs.Role != "" {
+		parts = append(parts, fmt.Sprintf("role %q", bs.Role))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package revisor_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+)
+
+func TestCoverage(t *testing.T) {
+	regenerate := regenerateGoldenFiles()
+
+	testConstraints := decodeConstraintSets(t,
+		"testdata/constraints/geo.json",
+	)
+
+	testValidator, err := revisor.NewValidator(testConstraints...)
+	mustf(t, err, "failed to create test validator")
+
+	var document newsdoc.Document
+
+	err = internal.UnmarshalFile("testdata/geo.json", &document)
+	mustf(t, err, "unmarshal geo doc")
+
+	collector := revisor.NewCoverageCollector(testValidator)
+
+	ctx := context.Background()
+
+	_, err = testValidator.ValidateDocument(ctx, &document,
+		revisor.WithCoverage(collector))
+	mustf(t, err, "validate document")
+
+	var (
+		goldenPath = "testdata/results-coverage/geo.json"
+		got        = collector.Entries()
+		want       []revisor.CoverageEntry
+	)
+
+	if regenerate {
+		data, err := json.MarshalIndent(got, "", "  ")
+		mustf(t, err, "marshal for golden reference file")
+
+		data = append(data, '\n')
+
+		err = os.WriteFile(goldenPath, data, 0o600)
+		mustf(t, err, "write golden reference file")
+	}
+
+	err = internal.UnmarshalFile(goldenPath, &want)
+	mustf(t, err, "unmarshal golden file")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("coverage mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, e := range collector.Unused() {
+		if e.Count != 0 {
+			t.Errorf("unused entry %s %s has a count of %d",
+				e.ConstraintSet, e.Path, e.Count)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package revisor
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FormatFunc validates a value of a custom string format, and returns an
+// error describing the problem if the value is invalid.
+type FormatFunc func(value string) error
+
+// CustomFormat is a string format that is implemented by the application,
+// f.ex. "tt-article-id".
+type CustomFormat struct {
+	// Name is the name of the format as used in constraint sets.
+	Name StringFormat
+	// Description is a short description of the format that follows
+	// "must be", f.ex. "an article ID".
+	Description string
+	// Validate is called to validate values of the format.
+	Validate FormatFunc
+}
+
+// FormatRegistry holds the custom string formats of a validator. A nil
+// registry has no custom formats.
+type FormatRegistry struct {
+	formats map[StringFormat]CustomFormat
+}
+
+// NewFormatRegistry creates a registry with the given custom formats. Custom
+// formats can't replace the built-in formats.
+func NewFormatRegistry(formats ...CustomFormat) (*FormatRegistry, error) {
+	r := FormatRegistry{
+		formats: make(map[StringFormat]CustomFormat, len(formats)),
+	}
+
+	for _, f := range formats {
+		switch {
+		case f.Name == StringFormatNone:
+			return nil, errors.New("custom formats must have a name")
+		case f.Name.Describe() != "":
+			return nil, fmt.Errorf(
+				"%q is a built-in format and can't be replaced", f.Name)
+		case f.Validate == nil:
+			return nil, fmt.Errorf(
+				"custom format %q has no validation function", f.Name)
+		}
+
+		_, exists := r.formats[f.Name]
+		if exists {
+			return nil, fmt.Errorf("custom format %q registered twice", f.Name)
+		}
+
+		r.formats[f.Name] = f
+	}
+
+	return &r, nil
+}
+
+// Lookup returns the custom format with the given name.
+func (r *FormatRegistry) Lookup(name StringFormat) (CustomFormat, bool) {
+	if r == nil {
+		return CustomFormat{}, false
+	}
+
+	f, ok := r.formats[name]
+
+	return f, ok
+}
+
+// Formats returns the custom formats sorted by name.
+func (r *FormatRegistry) Formats() []CustomFormat {
+	if r == nil {
+		return nil
+	}
+
+	list := make([]CustomFormat, 0, len(r.formats))
+
+	for _, f := range r.formats {
+		list = append(list, f)
+	}
+
+	slices.SortFunc(list, func(a, b CustomFormat) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+
+	return list
+}
+
+// Describe returns a description of a built-in or custom format. Unknown
+// formats are described by their name.
+func (r *FormatRegistry) Describe(name StringFormat) string {
+	if name == StringFormatNone {
+		return ""
+	}
+
+	desc := name.Describe()
+	if desc != "" {
+		return desc
+	}
+
+	f, ok := r.Lookup(name)
+	if ok && f.Description != "" {
+		return f.Description
+	}
+
+	return fmt.Sprintf("a value in the %q format", string(name))
+}
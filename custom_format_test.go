@@ -0,0 +1,231 @@
+package revisor_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+)
+
+var articleIDFormat = revisor.CustomFormat{
+	Name:        "tt-article-id",
+	Description: "an article ID",
+	Validate: func(value string) error {
+		if !strings.HasPrefix(value, "art-") {
+			return errors.New(`expected the prefix "art-"`)
+		}
+
+		return nil
+	},
+}
+
+func customFormatConstraints() revisor.ConstraintSet {
+	return revisor.ConstraintSet{
+		Version: 1,
+		Name:    "custom-format",
+		Documents: []revisor.DocumentConstraint{
+			{
+				Declares: "test/article",
+				Attributes: revisor.MakeConstraintMap(
+					map[string]revisor.StringConstraint{
+						"title": {AllowEmpty: true},
+					},
+				),
+				Links: []*revisor.BlockConstraint{
+					{
+						Declares: &revisor.BlockSignature{
+							Type: "test/article",
+							Rel:  "related",
+						},
+						Attributes: revisor.MakeConstraintMap(
+							map[string]revisor.StringConstraint{
+								"uri": {Format: "tt-article-id"},
+							},
+						),
+					},
+				},
+				Content: []*revisor.BlockConstraint{
+					{
+						Declares: &revisor.BlockSignature{
+							Type: "test/text",
+						},
+						Data: revisor.MakeConstraintMap(
+							map[string]revisor.StringConstraint{
+								"text": {Format: revisor.StringFormatHTML},
+							},
+						),
+					},
+				},
+			},
+		},
+		HTMLPolicies: []revisor.HTMLPolicy{{
+			Name: "default",
+			Elements: map[string]revisor.HTMLElement{
+				"a": {
+					Attributes: revisor.MakeConstraintMap(
+						map[string]revisor.StringConstraint{
+							"id": {Format: "tt-article-id"},
+						},
+					),
+				},
+			},
+		}},
+	}
+}
+
+func customFormatDocument(linkURI string, text string) *newsdoc.Document {
+	return &newsdoc.Document{
+		UUID:  "00000000-0000-0000-0000-000000000001",
+		Type:  "test/article",
+		Title: "Test Article",
+		Links: []newsdoc.Block{
+			{
+				Type: "test/article",
+				Rel:  "related",
+				URI:  linkURI,
+			},
+		},
+		Content: []newsdoc.Block{
+			{
+				Type: "test/text",
+				Data: map[string]string{
+					"text": text,
+				},
+			},
+		},
+	}
+}
+
+func TestCustomFormat(t *testing.T) {
+	ctx := context.Background()
+
+	base := newTestValidator(t, customFormatConstraints())
+
+	validator, err := base.WithFormats(articleIDFormat)
+	mustf(t, err, "add custom formats")
+
+	res, err := validator.ValidateDocument(ctx, customFormatDocument(
+		"art-1", `<a id="art-2">link</a>`))
+	mustf(t, err, "validate valid document")
+
+	if len(res) != 0 {
+		t.Fatalf("expected no validation errors, got: %v", res)
+	}
+
+	res, err = validator.ValidateDocument(ctx, customFormatDocument(
+		"img-1", `<a id="img-2">link</a>`))
+	mustf(t, err, "validate invalid document")
+
+	wantErrors := []string{
+		`invalid tt-article-id value "img-1": expected the prefix "art-"`,
+		`<a> attribute "id": invalid tt-article-id value "img-2"`,
+	}
+
+	if len(res) != len(wantErrors) {
+		t.Fatalf("expected %d validation errors, got: %v",
+			len(wantErrors), res)
+	}
+
+	for i, want := range wantErrors {
+		if !strings.Contains(res[i].Error, want) {
+			t.Errorf("expected error %d to contain %q, got %q",
+				i+1, want, res[i].Error)
+		}
+	}
+
+	res, err = base.ValidateDocument(ctx, customFormatDocument(
+		"art-1", "text"))
+	mustf(t, err, "validate without custom formats")
+
+	if len(res) != 1 || !strings.Contains(res[0].Error,
+		`unknown string format "tt-article-id"`) {
+		t.Fatalf("expected an unknown format error, got: %v", res)
+	}
+
+	withConstraints, err := validator.WithConstraints()
+	mustf(t, err, "add constraints")
+
+	res, err = withConstraints.ValidateDocument(ctx, customFormatDocument(
+		"art-1", "text"))
+	mustf(t, err, "validate after adding constraints")
+
+	if len(res) != 0 {
+		t.Fatalf("expected custom formats to be kept, got: %v", res)
+	}
+}
+
+func TestCustomFormatRequirement(t *testing.T) {
+	formats, err := revisor.NewFormatRegistry(articleIDFormat)
+	mustf(t, err, "create format registry")
+
+	c := revisor.StringConstraint{Format: "tt-article-id"}
+
+	got := c.RequirementWithFormats(formats)
+	if got != "is an article ID" {
+		t.Errorf("unexpected requirement %q", got)
+	}
+
+	got = c.Requirement()
+	if got != `is a value in the "tt-article-id" format` {
+		t.Errorf("unexpected requirement without formats %q", got)
+	}
+}
+
+func TestCustomFormatCountRequirement(t *testing.T) {
+	ctx := context.Background()
+
+	constraints := customFormatConstraints()
+
+	constraints.Documents[0].Meta = []*revisor.BlockConstraint{
+		{
+			Match: revisor.MakeConstraintMap(
+				map[string]revisor.StringConstraint{
+					"value": {Format: "tt-article-id"},
+				},
+			),
+			MinCount: intPtr(1),
+		},
+	}
+
+	validator, err := newTestValidator(t, constraints).WithFormats(
+		articleIDFormat)
+	mustf(t, err, "add custom formats")
+
+	res, err := validator.ValidateDocument(ctx, customFormatDocument(
+		"art-1", "text"))
+	mustf(t, err, "validate document")
+
+	want := "there must be 1 or more meta blocks where value is an article ID"
+
+	if len(res) != 1 || res[0].Error != want {
+		t.Fatalf("expected the error %q, got: %v", want, res)
+	}
+}
+
+func TestCustomFormatRegistration(t *testing.T) {
+	cases := map[string][]revisor.CustomFormat{
+		"missing name": {{
+			Validate: articleIDFormat.Validate,
+		}},
+		"built-in format": {{
+			Name:     revisor.StringFormatUUID,
+			Validate: articleIDFormat.Validate,
+		}},
+		"missing function": {{
+			Name: "tt-article-id",
+		}},
+		"duplicate": {articleIDFormat, articleIDFormat},
+	}
+
+	for name, formats := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := revisor.NewFormatRegistry(formats...)
+			if err == nil {
+				t.Fatal("expected registration to fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package revisor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ttab/newsdoc"
+)
+
+// DeprecationReplacement describes what should be used instead of a
+// deprecated entity.
+type DeprecationReplacement struct {
+	// Block is the signature of the block that replaces a deprecated
+	// block.
+	Block *BlockSignature `json:"block,omitempty"`
+	// Attribute is the name of the attribute or data key that replaces a
+	// deprecated attribute or data key.
+	Attribute string `json:"attribute,omitempty"`
+	// Value is the value that replaces a deprecated value, f.ex. an enum
+	// value.
+	Value *string `json:"value,omitempty"`
+}
+
+// String returns a human readable description of the replacement.
+func (dr DeprecationReplacement) String() string {
+	var parts []string
+
+	if dr.Block != nil {
+		var sig []string
+
+		if dr.Block.Type != "" {
+			sig = append(sig, fmt.Sprintf("type %q", dr.Block.Type))
+		}
+
+		if dr.Block.Rel != "" {
+			sig = append(sig, fmt.Sprintf("rel %q", dr.Block.Rel))
+		}
+
+		if dr.Block.Role != "" {
+			sig = append(sig, fmt.Sprintf("role %q", dr.Block.Role))
+		}
+
+		parts = append(parts, "a block with "+strings.Join(sig, ", "))
+	}
+
+	if dr.Attribute != "" {
+		parts = append(parts, fmt.Sprintf("the attribute %q", dr.Attribute))
+	}
+
+	if dr.Value != nil {
+		parts = append(parts, fmt.Sprintf("the value %q", *dr.Value))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return "use " + strings.Join(parts, " and ") + " instead"
+}
+
+// Description returns the deprecation documentation followed by the
+// replacement and sunset time, if they have been specified.
+func (d Deprecation) Description() string {
+	var sentences []string
+
+	if d.Replacement != nil {
+		if r := d.Replacement.String(); r != "" {
+			sentences = append(sentences,
+				strings.ToUpper(r[:1])+r[1:]+".")
+		}
+	}
+
+	if d.Sunset != nil {
+		sentences = append(sentences,
+			"Enforced from "+d.Sunset.Format(time.RFC3339)+".")
+	}
+
+	desc := strings.TrimSpace(d.Doc)
+
+	if desc != "" && len(sentences) > 0 && !strings.HasSuffix(desc, ".") {
+		desc += "."
+	}
+
+	return strings.TrimSpace(desc + " " + strings.Join(sentences, " "))
+}
+
+// SunsetOption configures a handler created by SunsetDeprecationHandler.
+type SunsetOption func(h *sunsetHandler)
+
+// WithSunsetClock sets the function that is used to get the current time,
+// defaults to time.Now.
+func WithSunsetClock(now func() time.Time) SunsetOption {
+	return func(h *sunsetHandler) {
+		h.now = now
+	}
+}
+
+// WithSunsetFallback sets a handler that decides how deprecations without a
+// sunset, or with a sunset in the future, are handled. Without a fallback such
+// deprecations aren't enforced.
+func WithSunsetFallback(fn DeprecationHandlerFunc) SunsetOption {
+	return func(h *sunsetHandler) {
+		h.fallback = fn
+	}
+}
+
+type sunsetHandler struct {
+	now      func() time.Time
+	fallback DeprecationHandlerFunc
+}
+
+// SunsetDeprecationHandler returns a deprecation handler that enforces
+// deprecations once their sunset time has passed.
+func SunsetDeprecationHandler(opts ...SunsetOption) DeprecationHandlerFunc {
+	h := sunsetHandler{
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	return h.handle
+}
+
+func (h *sunsetHandler) handle(
+	ctx context.Context,
+	doc *newsdoc.Document, deprecation Deprecation, c DeprecationContext,
+) (DeprecationDecision, error) {
+	if deprecation.Sunset != nil && !h.now().Before(*deprecation.Sunset) {
+		return DeprecationDecision{
+			Enforce: true,
+			Message: deprecation.Description(),
+		}, nil
+	}
+
+	if h.fallback != nil {
+		return h.fallback(ctx, doc, deprecation, c)
+	}
+
+	return DeprecationDecision{}, nil
+}
@@ -0,0 +1,233 @@
+package revisor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ttab/newsdoc"
+)
+
+// DeprecationReport describes how a set of documents used deprecations.
+type DeprecationReport struct {
+	// Checked is the number of documents that have been checked using
+	// DeprecationCollector.ValidateDocument.
+	Checked int `json:"checked"`
+	// Affected is the number of checked documents that used at least one
+	// deprecation.
+	Affected     int                `json:"affected"`
+	Deprecations []DeprecationUsage `json:"deprecations"`
+}
+
+// DeprecationUsage describes how a deprecation was used in the validated
+// documents.
+type DeprecationUsage struct {
+	Label       string                  `json:"label"`
+	Doc         string                  `json:"doc,omitempty"`
+	Replacement *DeprecationReplacement `json:"replacement,omitempty"`
+	Sunset      *time.Time              `json:"sunset,omitempty"`
+	// Uses is the number of times that the deprecation was triggered.
+	Uses int `json:"uses"`
+	// Documents is the number of documents that used the deprecation.
+	Documents     int                    `json:"documents"`
+	DocumentTypes []DeprecationTypeUsage `json:"documentTypes"`
+	// Examples contains the UUIDs of some of the documents that used the
+	// deprecation.
+	Examples []string `json:"examples,omitempty"`
+}
+
+// DeprecationTypeUsage describes how a deprecation was used by documents of a
+// specific type.
+type DeprecationTypeUsage struct {
+	Type      string `json:"type"`
+	Uses      int    `json:"uses"`
+	Documents int    `json:"documents"`
+}
+
+// DeprecationCollectorOption configures a DeprecationCollector.
+type DeprecationCollectorOption func(c *DeprecationCollector)
+
+// WithDeprecationExamples sets the maximum number of example document UUIDs
+// that are collected per deprecation, defaults to 5.
+func WithDeprecationExamples(n int) DeprecationCollectorOption {
+	return func(c *DeprecationCollector) {
+		c.maxExamples = n
+	}
+}
+
+// DeprecationCollector aggregates the deprecations that are triggered when
+// validating a set of documents. It's safe to use the same collector for
+// concurrent validations.
+type DeprecationCollector struct {
+	m           sync.Mutex
+	maxExamples int
+	usage       map[string]*deprecationUsage
+	checked     int
+	affected    int
+}
+
+type deprecationUsage struct {
+	DeprecationUsage
+
+	types map[string]*DeprecationTypeUsage
+	seen  map[string]bool
+}
+
+// NewDeprecationCollector creates a collector for deprecation usage.
+func NewDeprecationCollector(opts ...DeprecationCollectorOption) *DeprecationCollector {
+	c := DeprecationCollector{
+		maxExamples: 5,
+		usage:       make(map[string]*deprecationUsage),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+// Handler returns a deprecation handler that records the deprecations. The
+// decision is delegated to next, deprecations aren't enforced if next is nil.
+func (c *DeprecationCollector) Handler(next DeprecationHandlerFunc) DeprecationHandlerFunc {
+	return func(
+		ctx context.Context,
+		doc *newsdoc.Document, deprecation Deprecation, dc DeprecationContext,
+	) (DeprecationDecision, error) {
+		c.record(doc, deprecation)
+
+		if next == nil {
+			return DeprecationDecision{}, nil
+		}
+
+		return next(ctx, doc, deprecation, dc)
+	}
+}
+
+// ValidateDocument validates the document and records the deprecations that
+// it uses, and counts it as a checked document. The decision is delegated to
+// next, deprecations aren't enforced if next is nil.
+func (c *DeprecationCollector) ValidateDocument(
+	ctx context.Context, v *Validator, doc *newsdoc.Document,
+	next DeprecationHandlerFunc, opts ...ValidationOptionFunc,
+) ([]ValidationResult, error) {
+	handler := c.Handler(next)
+
+	var used bool
+
+	opts = append(slices.Clone(opts), WithDeprecationHandler(func(
+		ctx context.Context,
+		doc *newsdoc.Document, deprecation Deprecation, dc DeprecationContext,
+	) (DeprecationDecision, error) {
+		used = true
+
+		return handler(ctx, doc, deprecation, dc)
+	}))
+
+	res, err := v.ValidateDocument(ctx, doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.checked++
+
+	if used {
+		c.affected++
+	}
+
+	return res, nil
+}
+
+func (c *DeprecationCollector) record(doc *newsdoc.Document, d Deprecation) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	u, ok := c.usage[d.Label]
+	if !ok {
+		u = &deprecationUsage{
+			DeprecationUsage: DeprecationUsage{
+				Label:       d.Label,
+				Doc:         d.Doc,
+				Replacement: d.Replacement,
+				Sunset:      d.Sunset,
+			},
+			types: make(map[string]*DeprecationTypeUsage),
+			seen:  make(map[string]bool),
+		}
+
+		c.usage[d.Label] = u
+	}
+
+	t, ok := u.types[doc.Type]
+	if !ok {
+		t = &DeprecationTypeUsage{Type: doc.Type}
+		u.types[doc.Type] = t
+	}
+
+	u.Uses++
+	t.Uses++
+
+	// Documents without a UUID can't be told apart, so every use is
+	// counted as a separate document.
+	if doc.UUID != "" && u.seen[doc.UUID] {
+		return
+	}
+
+	if doc.UUID != "" {
+		u.seen[doc.UUID] = true
+	}
+
+	u.Documents++
+	t.Documents++
+
+	if doc.UUID != "" && len(u.Examples) < c.maxExamples {
+		u.Examples = append(u.Examples, doc.UUID)
+	}
+}
+
+// Report returns the collected deprecation usage. The deprecations are ordered
+// by label, and the document types of each deprecation are ordered by the
+// number of documents that used the deprecation. Only documents that were
+// checked using ValidateDocument are included in the document totals.
+func (c *DeprecationCollector) Report() DeprecationReport {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	report := make([]DeprecationUsage, 0, len(c.usage))
+
+	for _, u := range c.usage {
+		entry := u.DeprecationUsage
+
+		entry.Examples = slices.Clone(u.Examples)
+		entry.DocumentTypes = make([]DeprecationTypeUsage, 0, len(u.types))
+
+		for _, t := range u.types {
+			entry.DocumentTypes = append(entry.DocumentTypes, *t)
+		}
+
+		slices.SortFunc(entry.DocumentTypes, func(a, b DeprecationTypeUsage) int {
+			if a.Documents != b.Documents {
+				return b.Documents - a.Documents
+			}
+
+			return strings.Compare(a.Type, b.Type)
+		})
+
+		report = append(report, entry)
+	}
+
+	slices.SortFunc(report, func(a, b DeprecationUsage) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+
+	return DeprecationReport{
+		Checked:      c.checked,
+		Affected:     c.affected,
+		Deprecations: report,
+	}
+}
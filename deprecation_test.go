@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/ttab/newsdoc"
@@ -115,3 +117,262 @@ func TestDeprecation(t *testing.T) {
 		}
 	})
 }
+
+func TestSunsetDeprecationHandler(t *testing.T) {
+	constraints := decodeConstraintSets(t,
+		"testdata/constraints/migrations.json",
+	)
+
+	validator, err := revisor.NewValidator(constraints...)
+	mustf(t, err, "create validator")
+
+	var document newsdoc.Document
+
+	err = internal.UnmarshalFile("testdata/migration.json", &document)
+	mustf(t, err, "unmarshal document")
+
+	var fallbackLabels []string
+
+	fallback := func(
+		_ context.Context, _ *newsdoc.Document,
+		deprecation revisor.Deprecation,
+		_ revisor.DeprecationContext,
+	) (revisor.DeprecationDecision, error) {
+		fallbackLabels = append(fallbackLabels, deprecation.Label)
+
+		return revisor.DeprecationDecision{}, nil
+	}
+
+	validate := func(now time.Time) []revisor.ValidationResult {
+		t.Helper()
+
+		fallbackLabels = nil
+
+		handler := revisor.SunsetDeprecationHandler(
+			revisor.WithSunsetClock(func() time.Time { return now }),
+			revisor.WithSunsetFallback(fallback),
+		)
+
+		res, err := validator.ValidateDocument(
+			context.Background(), &document,
+			revisor.WithDeprecationHandler(handler))
+		mustf(t, err, "validate document")
+
+		return res
+	}
+
+	res := validate(time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC))
+	if len(res) != 0 {
+		t.Fatalf("expected no enforced deprecations before the sunset, got: %v", res)
+	}
+
+	if !slices.Contains(fallbackLabels, "about-topic") {
+		t.Fatalf("expected the fallback to handle about-topic, got: %v",
+			fallbackLabels)
+	}
+
+	res = validate(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	aboutLink := revisor.EntityRef{
+		RefType:   revisor.RefTypeBlock,
+		BlockKind: revisor.BlockKindLink,
+		Type:      "test/topic",
+		Rel:       "about",
+	}
+
+	want := []revisor.ValidationResult{
+		{
+			// Block deprecations are reported with the block ref
+			// added by the block itself and by its parent.
+			Entity:              []revisor.EntityRef{aboutLink, aboutLink},
+			Error:               `enforced deprecation "about-topic": Use subject links for topics. Use a block with type "test/topic", rel "subject" instead. Enforced from 2026-06-01T00:00:00Z.`,
+			EnforcedDeprecation: true,
+		},
+	}
+
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Fatalf("enforcement mismatch (-want +got):\n%s", diff)
+	}
+
+	if slices.Contains(fallbackLabels, "about-topic") {
+		t.Fatal("expected the fallback to not be called after the sunset")
+	}
+}
+
+func TestDeprecationCollector(t *testing.T) {
+	constraints := decodeConstraintSets(t,
+		"testdata/constraints/migrations.json",
+	)
+
+	validator, err := revisor.NewValidator(constraints...)
+	mustf(t, err, "create validator")
+
+	var document newsdoc.Document
+
+	err = internal.UnmarshalFile("testdata/migration.json", &document)
+	mustf(t, err, "unmarshal document")
+
+	collector := revisor.NewDeprecationCollector(
+		revisor.WithDeprecationExamples(1))
+
+	var enforced int
+
+	next := func(
+		_ context.Context, _ *newsdoc.Document,
+		_ revisor.Deprecation, _ revisor.DeprecationContext,
+	) (revisor.DeprecationDecision, error) {
+		enforced++
+
+		return revisor.DeprecationDecision{Enforce: true}, nil
+	}
+
+	other := document
+	other.UUID = "0c5bb8f2-29a6-4b0e-9f36-3cb7d36d8a4f"
+
+	clean := newsdoc.Document{
+		UUID: "7f0d7b3e-9e4c-4d55-8f0e-2a6b1d3c9e10",
+		Type: "test/story",
+	}
+
+	for _, doc := range []*newsdoc.Document{&document, &document, &other, &clean} {
+		_, err := collector.ValidateDocument(context.Background(),
+			validator, doc, next)
+		mustf(t, err, "validate document")
+	}
+
+	report := collector.Report()
+
+	if report.Checked != 4 || report.Affected != 3 {
+		t.Fatalf("expected 3 of 4 checked documents to be affected, got %d of %d",
+			report.Affected, report.Checked)
+	}
+
+	var labels []string
+
+	for _, u := range report.Deprecations {
+		labels = append(labels, u.Label)
+	}
+
+	if diff := cmp.Diff([]string{
+		"about-topic", "priority-level", "sport-section",
+	}, labels); diff != "" {
+		t.Fatalf("deprecation labels mismatch (-want +got):\n%s", diff)
+	}
+
+	sunset := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	want := revisor.DeprecationUsage{
+		Label:     "priority-level",
+		Doc:       "Use the value attribute",
+		Uses:      6,
+		Documents: 2,
+		DocumentTypes: []revisor.DeprecationTypeUsage{
+			{Type: "test/story", Uses: 6, Documents: 2},
+		},
+		Examples: []string{document.UUID},
+	}
+
+	if diff := cmp.Diff(want, report.Deprecations[1]); diff != "" {
+		t.Fatalf("deprecation usage mismatch (-want +got):\n%s", diff)
+	}
+
+	if report.Deprecations[0].Sunset == nil ||
+		!report.Deprecations[0].Sunset.Equal(sunset) {
+		t.Fatalf("expected the sunset to be reported, got: %v",
+			report.Deprecations[0].Sunset)
+	}
+
+	if enforced != 12 {
+		t.Fatalf("expected all deprecations to be passed on, got %d", enforced)
+	}
+}
+
+func TestHTMLAttributeDeprecation(t *testing.T) {
+	cs := revisor.ConstraintSet{
+		Name: "html",
+		Documents: []revisor.DocumentConstraint{{
+			Declares: "test/html",
+			Content: []*revisor.BlockConstraint{{
+				Declares: &revisor.BlockSignature{Type: "test/text"},
+				Data: revisor.MakeConstraintMap(
+					map[string]revisor.StringConstraint{
+						"text": {Format: revisor.StringFormatHTML},
+					},
+				),
+			}},
+		}},
+		Enums: []revisor.Enum{{
+			Declare: "test-rel",
+			Values: map[string]revisor.EnumConstraint{
+				"nofollow": {},
+				"external": {
+					Deprecated: &revisor.Deprecation{
+						Label: "external-rel",
+						Doc:   "Use nofollow",
+					},
+				},
+			},
+		}},
+		HTMLPolicies: []revisor.HTMLPolicy{{
+			Name: "default",
+			Elements: map[string]revisor.HTMLElement{
+				"a": {
+					Attributes: revisor.MakeConstraintMap(
+						map[string]revisor.StringConstraint{
+							"href": {},
+							"rel": {
+								Optional: true,
+								EnumRef:  "test-rel",
+							},
+						},
+					),
+				},
+			},
+		}},
+	}
+
+	validator, err := revisor.NewValidator(cs)
+	mustf(t, err, "create validator")
+
+	doc := newsdoc.Document{
+		UUID: "9d5c4f53-1b0a-4c8e-a5f5-4a0f1c3f2d2b",
+		Type: "test/html",
+		Content: []newsdoc.Block{{
+			Type: "test/text",
+			Data: map[string]string{
+				"text": `<a href="https://example.com" rel="external">x</a>`,
+			},
+		}},
+	}
+
+	var got []revisor.DeprecationContext
+
+	res, err := validator.ValidateDocument(context.Background(), &doc,
+		revisor.WithDeprecationHandler(func(
+			_ context.Context, _ *newsdoc.Document,
+			deprecation revisor.Deprecation, c revisor.DeprecationContext,
+		) (revisor.DeprecationDecision, error) {
+			if deprecation.Label == "external-rel" {
+				got = append(got, c)
+			}
+
+			return revisor.DeprecationDecision{}, nil
+		}))
+	mustf(t, err, "validate document")
+
+	if len(res) != 0 {
+		t.Fatalf("expected the document to be valid, got: %v", res)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected one HTML deprecation, got: %v", got)
+	}
+
+	c := got[0]
+
+	if c.HTML == nil || c.HTML.Element != "a" || c.HTML.Attribute != "rel" ||
+		c.Value == nil || *c.Value != "external" ||
+		c.Entity == nil || c.Entity.Name != "text" {
+		t.Fatalf("unexpected deprecation context: %#v", c)
+	}
+}
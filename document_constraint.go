@@ -22,6 +22,8 @@ type DocumentConstraint struct {
 	Content    []*BlockConstraint `json:"content,omitempty"`
 	Attributes ConstraintMap      `json:"attributes,omitempty"`
 	Deprecated *Deprecation       `json:"deprecated,omitempty"`
+
+	spec specRef
 }
 
 // BlockConstraints implements the BlockConstraintsSet interface.
@@ -0,0 +1,503 @@
+package revisor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ttab/newsdoc"
+)
+
+// DocumentMigration describes how documents that use deprecated parts of a
+// specification can be rewritten to use their replacements.
+type DocumentMigration struct {
+	// ID identifies the migration, it must be unique across all loaded
+	// constraint sets.
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// Documents limits the migration to the listed document types. The
+	// migration applies to all documents if no types are given.
+	Documents []string         `json:"documents,omitempty"`
+	Blocks    []BlockMigration `json:"blocks,omitempty"`
+	Enums     []EnumMigration  `json:"enums,omitempty"`
+}
+
+// BlockMigration rewrites the blocks that match its attribute constraints.
+// Block migrations are applied to nested blocks as well.
+type BlockMigration struct {
+	// Kind limits the migration to blocks of the given kind.
+	Kind BlockKind `json:"kind,omitempty"`
+	// Match is checked against the attributes of the block in the same way
+	// as BlockConstraint.Match.
+	Match ConstraintMap `json:"match"`
+	// Set assigns new values to block attributes, f.ex. to rename the type
+	// or rel of a block.
+	Set map[string]string `json:"set,omitempty"`
+	// DataToAttribute moves data values to block attributes. The keys are
+	// data keys and the values are attribute names. A value isn't moved if
+	// the attribute already has a different value.
+	DataToAttribute map[string]string `json:"dataToAttribute,omitempty"`
+}
+
+// EnumMigration replaces old enum values with new ones in all attributes and
+// data values that reference the enum.
+type EnumMigration struct {
+	Enum string `json:"enum"`
+	// Values maps old values to new ones.
+	Values map[string]string `json:"values"`
+}
+
+// MigrationChange describes a change that a migration made to a document.
+type MigrationChange struct {
+	Migration   string      `json:"migration"`
+	Entity      []EntityRef `json:"entity,omitempty"`
+	Description string      `json:"description"`
+	// Skipped is set if the change couldn't be made, and the document
+	// has to be fixed manually.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+func (mc MigrationChange) String() string {
+	desc := mc.Description
+
+	if mc.Skipped {
+		desc = "skipped: " + desc
+	}
+
+	if len(mc.Entity) > 0 {
+		return mc.Migration + ": " + entityRefsToString(mc.Entity) + ": " + desc
+	}
+
+	return mc.Migration + ": " + desc
+}
+
+func validateMigrations(migrations []DocumentMigration) error {
+	ids := make(map[string]bool, len(migrations))
+
+	for i, m := range migrations {
+		if m.ID == "" {
+			return fmt.Errorf("migration %d must have an ID", i+1)
+		}
+
+		if ids[m.ID] {
+			return fmt.Errorf("migration %q is declared more than once", m.ID)
+		}
+
+		ids[m.ID] = true
+
+		for j, b := range m.Blocks {
+			err := b.validate()
+			if err != nil {
+				return fmt.Errorf("migration %q: block migration %d: %w",
+					m.ID, j+1, err)
+			}
+		}
+
+		for j, e := range m.Enums {
+			if e.Enum == "" {
+				return fmt.Errorf("migration %q: enum migration %d must name an enum",
+					m.ID, j+1)
+			}
+
+			if len(e.Values) == 0 {
+				return fmt.Errorf("migration %q: enum migration %d has no values",
+					m.ID, j+1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bm BlockMigration) validate() error {
+	if bm.Kind != "" && !slices.Contains(blockKinds, bm.Kind) {
+		return fmt.Errorf("unknown block kind %q", bm.Kind)
+	}
+
+	if len(bm.Match.Keys) == 0 {
+		return errors.New("a block migration must match at least one attribute")
+	}
+
+	if len(bm.Set) == 0 && len(bm.DataToAttribute) == 0 {
+		return errors.New("a block migration must set attributes or move data")
+	}
+
+	for name := range bm.Set {
+		if !slices.Contains(allBlockAttributes, blockAttributeKey(name)) {
+			return fmt.Errorf("cannot set unknown attribute %q", name)
+		}
+	}
+
+	for key, name := range bm.DataToAttribute {
+		if !slices.Contains(allBlockAttributes, blockAttributeKey(name)) {
+			return fmt.Errorf("cannot move the data value %q to unknown attribute %q",
+				key, name)
+		}
+	}
+
+	return nil
+}
+
+// collectMigrations adds the migrations of a constraint set to the validator.
+func (v *Validator) collectMigrations(cs ConstraintSet) error {
+	for i := range cs.Migrations {
+		m := &cs.Migrations[i]
+
+		for _, existing := range v.migrations {
+			if existing.ID == m.ID {
+				return fmt.Errorf("migration %q redeclared in %q",
+					m.ID, cs.Name)
+			}
+		}
+
+		v.migrations = append(v.migrations, m)
+	}
+
+	return nil
+}
+
+// checkMigrationEnums verifies that the enums that migrations reference have
+// been declared.
+func (v *Validator) checkMigrationEnums() error {
+	for _, m := range v.migrations {
+		for _, e := range m.Enums {
+			_, declared := v.enums.enums[e.Enum]
+			if !declared {
+				return fmt.Errorf("migration %q references the undeclared enum %q",
+					m.ID, e.Enum)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Migrations returns the IDs of the document migrations that have been
+// declared in the loaded constraint sets, in the order that they are applied.
+func (v *Validator) Migrations() []string {
+	ids := make([]string, len(v.migrations))
+
+	for i, m := range v.migrations {
+		ids[i] = m.ID
+	}
+
+	return ids
+}
+
+// Migrate applies the document migrations declared in the constraint sets to
+// the document, and returns the changes that were made. The document is
+// modified in place. Migrations are applied in the order that they were
+// declared, and block migrations are applied before enum migrations.
+//
+// Migrations can be limited to a subset by passing their IDs, an error is
+// returned if any of the IDs is unknown.
+func (v *Validator) Migrate(
+	document *newsdoc.Document, ids ...string,
+) ([]MigrationChange, error) {
+	err := v.checkMigrationIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []MigrationChange
+
+	// Match expressions can use enums, HTML and custom formats.
+	vCtx := v.specContext()
+
+	for _, m := range v.migrations {
+		if len(ids) > 0 && !slices.Contains(ids, m.ID) {
+			continue
+		}
+
+		if len(m.Documents) > 0 && !slices.Contains(m.Documents, document.Type) {
+			continue
+		}
+
+		mr := migrationRun{migration: m, vCtx: &vCtx}
+
+		for _, kind := range blockKinds {
+			mr.migrateBlocks(getDocumentBlocks(document, kind), kind, nil)
+		}
+
+		if len(m.Enums) > 0 {
+			v.migrateDocumentEnums(&mr, document)
+		}
+
+		changes = append(changes, mr.changes...)
+	}
+
+	return changes, nil
+}
+
+// checkMigrationIDs verifies that the IDs refer to declared migrations.
+func (v *Validator) checkMigrationIDs(ids []string) error {
+	known := v.Migrations()
+
+	for _, id := range ids {
+		if slices.Contains(known, id) {
+			continue
+		}
+
+		if len(known) == 0 {
+			return fmt.Errorf(
+				"unknown migration %q, the constraint sets declare no migrations", id)
+		}
+
+		return fmt.Errorf("unknown migration %q, known migrations are: %s",
+			id, quotedSlice(known))
+	}
+
+	return nil
+}
+
+type migrationRun struct {
+	migration *DocumentMigration
+	vCtx      *ValidationContext
+	changes   []MigrationChange
+}
+
+func (mr *migrationRun) change(
+	entity []EntityRef, skipped bool, format string, a ...any,
+) {
+	mr.changes = append(mr.changes, MigrationChange{
+		Migration:   mr.migration.ID,
+		Entity:      entity,
+		Description: fmt.Sprintf(format, a...),
+		Skipped:     skipped,
+	})
+}
+
+func blockRef(b *newsdoc.Block, kind BlockKind, index int) EntityRef {
+	return EntityRef{
+		RefType:   RefTypeBlock,
+		BlockKind: kind,
+		Index:     index,
+		Type:      b.Type,
+		Rel:       b.Rel,
+	}
+}
+
+// withParents returns a new entity path with the parent path appended.
+func withParents(ref EntityRef, parents []EntityRef) []EntityRef {
+	return append([]EntityRef{ref}, parents...)
+}
+
+func (mr *migrationRun) migrateBlocks(
+	blocks []newsdoc.Block, kind BlockKind, parents []EntityRef,
+) {
+	for i := range blocks {
+		b := &blocks[i]
+		path := withParents(blockRef(b, kind, i), parents)
+
+		for _, bm := range mr.migration.Blocks {
+			if bm.Kind != "" && bm.Kind != kind {
+				continue
+			}
+
+			if !bm.matches(b, mr.vCtx) {
+				continue
+			}
+
+			mr.migrateBlock(bm, b, path)
+		}
+
+		for _, childKind := range blockKinds {
+			mr.migrateBlocks(getNestedBlocks(b, childKind), childKind, path)
+		}
+	}
+}
+
+func (bm BlockMigration) matches(
+	b *newsdoc.Block, vCtx *ValidationContext,
+) bool {
+	for _, k := range bm.Match.Keys {
+		value, ok := blockMatchAttribute(b, k)
+
+		check := bm.Match.Constraints[k]
+
+		// Optional attributes are empty strings.
+		check.AllowEmpty = check.AllowEmpty || check.Optional
+
+		_, err := check.Validate(value, ok, vCtx)
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (mr *migrationRun) migrateBlock(
+	bm BlockMigration, b *newsdoc.Block, path []EntityRef,
+) {
+	for _, name := range slices.Sorted(maps.Keys(bm.Set)) {
+		value := bm.Set[name]
+
+		current, _ := blockAttribute(b, name)
+		if current == value {
+			continue
+		}
+
+		setBlockAttribute(b, name, value)
+
+		mr.change(withParents(EntityRef{
+			RefType: RefTypeAttribute,
+			Name:    name,
+		}, path), false, "changed from %q to %q", current, value)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(bm.DataToAttribute)) {
+		name := bm.DataToAttribute[key]
+
+		value, ok := b.Data[key]
+		if !ok {
+			continue
+		}
+
+		ref := withParents(EntityRef{
+			RefType: RefTypeData,
+			Name:    key,
+		}, path)
+
+		current, _ := blockAttribute(b, name)
+		if current != "" && current != value {
+			mr.change(ref, true,
+				"cannot move to the attribute %q, it already has the value %q",
+				name, current)
+
+			continue
+		}
+
+		setBlockAttribute(b, name, value)
+		delete(b.Data, key)
+
+		if len(b.Data) == 0 {
+			b.Data = nil
+		}
+
+		mr.change(ref, false, "moved to the attribute %q", name)
+	}
+}
+
+// migrateDocumentEnums applies the enum migrations to the attributes and data
+// that reference the migrated enums. The constraints that apply to the
+// attributes and blocks are found in the same way as during validation.
+func (v *Validator) migrateDocumentEnums(
+	mr *migrationRun, document *newsdoc.Document,
+) {
+	var blockConstraints []BlockConstraintSet
+
+	for i := range v.documents {
+		if v.documents[i].Matches(document, mr.vCtx) == NoMatch {
+			continue
+		}
+
+		blockConstraints = append(blockConstraints, v.documents[i])
+
+		for _, k := range v.documents[i].Attributes.Keys {
+			value, _ := documentAttribute(document, k)
+
+			newValue, ok := mr.enumValue(
+				v.documents[i].Attributes.Constraints[k], value)
+			if !ok {
+				continue
+			}
+
+			setDocumentAttribute(document, k, newValue)
+
+			mr.change([]EntityRef{{
+				RefType: RefTypeAttribute,
+				Name:    k,
+			}}, false, "changed from %q to %q", value, newValue)
+		}
+	}
+
+	counts := make(map[*BlockConstraint]int)
+
+	for _, kind := range blockKinds {
+		mr.migrateBlockEnums(getDocumentBlocks(document, kind), kind,
+			blockConstraints, counts, nil)
+	}
+}
+
+func (mr *migrationRun) migrateBlockEnums(
+	blocks []newsdoc.Block, kind BlockKind,
+	constraintSets []BlockConstraintSet,
+	counts map[*BlockConstraint]int,
+	parents []EntityRef,
+) {
+	for i := range blocks {
+		b := &blocks[i]
+		path := withParents(blockRef(b, kind, i), parents)
+
+		info := matchBlock(b, kind, constraintSets, counts)
+
+		for _, attrs := range info.matchedAttrConstraints {
+			for _, k := range attrs.Keys {
+				value, _ := blockAttribute(b, k)
+
+				newValue, ok := mr.enumValue(attrs.Constraints[k], value)
+				if !ok {
+					continue
+				}
+
+				setBlockAttribute(b, k, newValue)
+
+				mr.change(withParents(EntityRef{
+					RefType: RefTypeAttribute,
+					Name:    k,
+				}, path), false, "changed from %q to %q", value, newValue)
+			}
+		}
+
+		for _, data := range info.matchedDataConstraints {
+			for _, k := range data.Keys {
+				value, exists := b.Data[k]
+				if !exists {
+					continue
+				}
+
+				newValue, ok := mr.enumValue(data.Constraints[k], value)
+				if !ok {
+					continue
+				}
+
+				b.Data[k] = newValue
+
+				mr.change(withParents(EntityRef{
+					RefType: RefTypeData,
+					Name:    k,
+				}, path), false, "changed from %q to %q", value, newValue)
+			}
+		}
+
+		for _, childKind := range blockKinds {
+			mr.migrateBlockEnums(getNestedBlocks(b, childKind), childKind,
+				info.matchedConstraints, counts, path)
+		}
+	}
+}
+
+// enumValue returns the replacement value if the constraint references an
+// enum that the migration maps the value for.
+func (mr *migrationRun) enumValue(
+	c StringConstraint, value string,
+) (string, bool) {
+	if c.EnumRef == "" || value == "" {
+		return "", false
+	}
+
+	for _, e := range mr.migration.Enums {
+		if e.Enum != c.EnumRef {
+			continue
+		}
+
+		newValue, ok := e.Values[value]
+		if ok && newValue != value {
+			return newValue, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,263 @@
+package revisor_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+)
+
+type migrationResult struct {
+	Changes  []revisor.MigrationChange
+	Document newsdoc.Document
+}
+
+func TestMigrate(t *testing.T) {
+	regenerate := regenerateGoldenFiles()
+
+	constraints := decodeConstraintSets(t,
+		"testdata/constraints/migrations.json",
+	)
+
+	validator, err := revisor.NewValidator(constraints...)
+	mustf(t, err, "create validator")
+
+	var document newsdoc.Document
+
+	err = internal.UnmarshalFile("testdata/migration.json", &document)
+	mustf(t, err, "unmarshal document")
+
+	changes, err := validator.Migrate(&document)
+	mustf(t, err, "migrate document")
+
+	got := migrationResult{
+		Changes:  changes,
+		Document: document,
+	}
+
+	goldenPath := "testdata/results-migration/migration.json"
+
+	if regenerate {
+		data, err := json.MarshalIndent(got, "", "  ")
+		mustf(t, err, "marshal for golden reference file")
+
+		err = os.WriteFile(goldenPath, data, 0o600)
+		mustf(t, err, "write golden reference file")
+	}
+
+	var want migrationResult
+
+	err = internal.UnmarshalFile(goldenPath, &want)
+	mustf(t, err, "unmarshal golden file")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("migration result mismatch (-want +got):\n%s", diff)
+	}
+
+	res, err := validator.ValidateDocument(context.Background(), &document)
+	mustf(t, err, "validate migrated document")
+
+	if len(res) > 0 {
+		t.Fatalf("expected the migrated document to be valid, got: %v", res)
+	}
+
+	again, err := validator.Migrate(&document)
+	mustf(t, err, "migrate document again")
+
+	for _, change := range again {
+		if !change.Skipped {
+			t.Fatalf("expected a second migration to be a no-op, got: %v", change)
+		}
+	}
+}
+
+func TestMigrateSelected(t *testing.T) {
+	constraints := decodeConstraintSets(t,
+		"testdata/constraints/migrations.json",
+	)
+
+	validator, err := revisor.NewValidator(constraints...)
+	mustf(t, err, "create validator")
+
+	if diff := cmp.Diff([]string{
+		"subject-links", "priority-value", "sports-section",
+	}, validator.Migrations()); diff != "" {
+		t.Fatalf("migration IDs mismatch (-want +got):\n%s", diff)
+	}
+
+	var document newsdoc.Document
+
+	err = internal.UnmarshalFile("testdata/migration.json", &document)
+	mustf(t, err, "unmarshal document")
+
+	changes, err := validator.Migrate(&document, "sports-section")
+	mustf(t, err, "migrate document")
+
+	if len(changes) != 1 || changes[0].Migration != "sports-section" {
+		t.Fatalf("expected one change from sports-section, got: %v", changes)
+	}
+
+	if document.Links[0].Rel != "about" {
+		t.Fatal("expected other migrations to be left out")
+	}
+
+	_, err = validator.Migrate(&document, "sports-section", "sport-section")
+	if err == nil || !strings.Contains(err.Error(), `unknown migration "sport-section"`) {
+		t.Fatalf("expected an error for an unknown migration, got: %v", err)
+	}
+}
+
+func TestMigrationValidation(t *testing.T) {
+	cases := map[string]struct {
+		Set  string
+		Want string
+	}{
+		"MissingID": {
+			Set:  `{"name": "x", "migrations": [{"blocks": []}]}`,
+			Want: "migration 1 must have an ID",
+		},
+		"EmptyMatch": {
+			Set: `{"name": "x", "migrations": [{"id": "a", "blocks": [
+				{"match": {}, "set": {"type": "x"}}
+			]}]}`,
+			Want: "must match at least one attribute",
+		},
+		"UnknownAttribute": {
+			Set: `{"name": "x", "migrations": [{"id": "a", "blocks": [
+				{"match": {"type": {"const": "a"}}, "set": {"colour": "x"}}
+			]}]}`,
+			Want: `cannot set unknown attribute "colour"`,
+		},
+		"UndeclaredEnum": {
+			Set: `{"name": "x", "migrations": [{"id": "a", "enums": [
+				{"enum": "nope", "values": {"a": "b"}}
+			]}]}`,
+			Want: `references the undeclared enum "nope"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cs, err := revisor.DecodeConstraintSet(name+".json",
+				strings.NewReader(tc.Set))
+			mustf(t, err, "decode constraint set")
+
+			_, err = revisor.NewValidator(cs)
+			if err == nil || !strings.Contains(err.Error(), tc.Want) {
+				t.Fatalf("expected an error containing %q, got: %v",
+					tc.Want, err)
+			}
+		})
+	}
+
+	a := revisor.ConstraintSet{
+		Name: "a",
+		Migrations: []revisor.DocumentMigration{{
+			ID: "dup",
+			Enums: []revisor.EnumMigration{{
+				Enum: "e", Values: map[string]string{"a": "b"},
+			}},
+		}},
+	}
+	b := a
+	b.Name = "b"
+
+	_, err := revisor.NewValidator(a, b)
+	if err == nil || !strings.Contains(err.Error(), `migration "dup" redeclared in "b"`) {
+		t.Fatalf("expected an error for a redeclared migration, got: %v", err)
+	}
+}
+
+func TestMigrateMatchEnumReference(t *testing.T) {
+	cs, err := revisor.DecodeConstraintSet("enum-match.json", strings.NewReader(`{
+  "name": "enum-match",
+  "enums": [
+    {"declare": "test-genre", "values": {"sports": {}, "culture": {}}}
+  ],
+  "migrations": [
+    {
+      "id": "genre-links",
+      "blocks": [
+        {
+          "kind": "link",
+          "match": {
+            "rel": {"const": "genre"},
+            "value": {"enumReference": "test-genre"}
+          },
+          "set": {"rel": "section"}
+        }
+      ]
+    }
+  ]
+}`))
+	mustf(t, err, "decode constraint set")
+
+	validator, err := revisor.NewValidator(cs)
+	mustf(t, err, "create validator")
+
+	document := newsdoc.Document{
+		Links: []newsdoc.Block{
+			{Rel: "genre", Value: "sports"},
+			{Rel: "genre", Value: "weather"},
+		},
+	}
+
+	changes, err := validator.Migrate(&document)
+	mustf(t, err, "migrate document")
+
+	if len(changes) != 1 || document.Links[0].Rel != "section" ||
+		document.Links[1].Rel != "genre" {
+		t.Fatalf("expected only the link with an enum value to be migrated, got: %v",
+			changes)
+	}
+}
+
+func TestMigrateEnumsVariant(t *testing.T) {
+	cs, err := revisor.DecodeConstraintSet("enum-variant.json", strings.NewReader(`{
+  "name": "enum-variant",
+  "documents": [
+    {
+      "declares": "test/article",
+      "attributes": {
+        "language": {"enumReference": "test-language"}
+      }
+    }
+  ],
+  "enums": [
+    {"declare": "test-language", "values": {"sv": {}, "en": {}}}
+  ],
+  "migrations": [
+    {
+      "id": "language-codes",
+      "enums": [
+        {"enum": "test-language", "values": {"se": "sv"}}
+      ]
+    }
+  ]
+}`))
+	mustf(t, err, "decode constraint set")
+
+	validator, err := revisor.NewValidator(cs)
+	mustf(t, err, "create validator")
+
+	validator = validator.WithVariants(revisor.Variant{Name: "template"})
+
+	document := newsdoc.Document{
+		Type:     "test/article#template",
+		Language: "se",
+	}
+
+	changes, err := validator.Migrate(&document)
+	mustf(t, err, "migrate document")
+
+	if len(changes) != 1 || document.Language != "sv" {
+		t.Fatalf("expected the variant document to be migrated, got: %v",
+			changes)
+	}
+}
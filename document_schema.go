@@ -0,0 +1,768 @@
+package revisor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// DocumentJSONSchema returns a JSON schema for documents of the given type.
+// Constraints that cannot be expressed in JSON schema, like HTML policies or
+// WKT geometries, are described in "$comment" annotations and have to be
+// checked by the validator.
+func (v *Validator) DocumentJSONSchema(docType string) (*jsonschema.Schema, error) {
+	spec, ok := v.DocumentSpec(docType)
+	if !ok {
+		return nil, fmt.Errorf("undeclared document type %q", docType)
+	}
+
+	g := newSchemaGenerator(v)
+
+	schema := g.documentSchema(spec)
+
+	schema.Version = jsonschema.Version
+	schema.Definitions = g.defs
+
+	return schema, nil
+}
+
+// DocumentsJSONSchema returns a JSON schema that accepts documents of all the
+// declared document types.
+func (v *Validator) DocumentsJSONSchema() *jsonschema.Schema {
+	g := newSchemaGenerator(v)
+
+	schema := jsonschema.Schema{
+		Version: jsonschema.Version,
+	}
+
+	for _, spec := range v.DocumentSpecs() {
+		name := "document-" + schemaDefName(spec.Type)
+
+		g.defs[name] = g.documentSchema(spec)
+
+		schema.OneOf = append(schema.OneOf, &jsonschema.Schema{
+			Ref: "#/$defs/" + name,
+		})
+	}
+
+	schema.Definitions = g.defs
+
+	return &schema
+}
+
+type schemaGenerator struct {
+	v    *Validator
+	defs jsonschema.Definitions
+}
+
+func newSchemaGenerator(v *Validator) *schemaGenerator {
+	return &schemaGenerator{
+		v: v,
+		defs: jsonschema.Definitions{
+			"empty": {
+				Type:      "string",
+				MaxLength: uint64Ptr(0),
+			},
+		},
+	}
+}
+
+func (g *schemaGenerator) documentSchema(spec *DocumentSpec) *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Type:                 "object",
+		Title:                spec.Name,
+		Description:          spec.Description,
+		Deprecated:           spec.Deprecated != nil,
+		Properties:           jsonschema.NewProperties(),
+		AdditionalProperties: jsonschema.FalseSchema,
+		Required:             []string{"uuid", "type"},
+	}
+
+	typeSchema := jsonschema.Schema{
+		Type:  "string",
+		Const: spec.Type,
+	}
+
+	if len(spec.VariantTypes) > 0 {
+		typeSchema.Const = nil
+		typeSchema.Enum = []any{spec.Type}
+
+		for _, t := range spec.VariantTypes {
+			typeSchema.Enum = append(typeSchema.Enum, t)
+		}
+	}
+
+	schema.Properties.Set("uuid", &jsonschema.Schema{
+		Type:   "string",
+		Format: "uuid",
+	})
+	schema.Properties.Set("type", &typeSchema)
+
+	for _, k := range []documentAttributeKey{
+		docAttrURI, docAttrURL, docAttrTitle, docAttrLanguage,
+	} {
+		name := string(k)
+
+		c, declared := spec.Attributes.Constraints[name]
+		if !declared {
+			schema.Properties.Set(name, &jsonschema.Schema{
+				Type: "string",
+			})
+
+			continue
+		}
+
+		schema.Properties.Set(name, g.stringSchema(c))
+
+		if !c.Optional && !c.AllowEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	g.setBlockLists(&schema, spec.Blocks, nil)
+
+	return &schema
+}
+
+// setBlockLists adds the block list properties to the schema. Lists are
+// omitted from the JSON when they are empty, so lists that require blocks
+// are marked as required. Extra blocks are allowed in the lists, but not
+// counted, this is used for blocks declared by conditional extensions.
+func (g *schemaGenerator) setBlockLists(
+	schema *jsonschema.Schema,
+	blocks func(kind BlockKind) []*BlockSpec,
+	extra func(kind BlockKind) []*BlockSpec,
+) {
+	for _, kind := range blockKinds {
+		specs := blocks(kind)
+		name := kindFields[kind]
+
+		var allowed []*BlockSpec
+
+		if extra != nil {
+			allowed = extra(kind)
+		}
+
+		schema.Properties.Set(name, g.blockListSchema(specs, allowed))
+
+		if slices.ContainsFunc(specs, requiresBlock) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func requiresBlock(spec *BlockSpec) bool {
+	return (spec.Count != nil && *spec.Count > 0) ||
+		(spec.MinCount != nil && *spec.MinCount > 0)
+}
+
+// blockListSchema returns a schema for a list of the blocks, with count
+// checks for the blocks. The extra blocks are allowed in the list but not
+// counted.
+func (g *schemaGenerator) blockListSchema(
+	specs []*BlockSpec, extra []*BlockSpec,
+) *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Type: "array",
+	}
+
+	if len(specs) == 0 && len(extra) == 0 {
+		schema.MaxItems = uint64Ptr(0)
+
+		return &schema
+	}
+
+	items := jsonschema.Schema{}
+
+	for _, spec := range extra {
+		items.AnyOf = append(items.AnyOf, g.blockSchema(spec))
+	}
+
+	for _, spec := range specs {
+		items.AnyOf = append(items.AnyOf, g.blockSchema(spec))
+
+		if spec.Count == nil && spec.MinCount == nil && spec.MaxCount == nil {
+			continue
+		}
+
+		count := jsonschema.Schema{
+			Contains:    signatureSchema(spec.Declares),
+			MinContains: uint64Ptr(0),
+		}
+
+		switch {
+		case spec.Count != nil:
+			count.MinContains = uint64Ptr(*spec.Count)
+			count.MaxContains = uint64Ptr(*spec.Count)
+		default:
+			if spec.MinCount != nil {
+				count.MinContains = uint64Ptr(*spec.MinCount)
+			}
+
+			if spec.MaxCount != nil {
+				count.MaxContains = uint64Ptr(*spec.MaxCount)
+			}
+		}
+
+		schema.AllOf = append(schema.AllOf, &count)
+	}
+
+	schema.Items = &items
+
+	return &schema
+}
+
+// signatureSchema returns a schema that matches blocks with the signature.
+func signatureSchema(sig BlockSignature) *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Properties: jsonschema.NewProperties(),
+	}
+
+	for _, attr := range []struct {
+		Name  string
+		Value string
+	}{
+		{Name: "type", Value: sig.Type},
+		{Name: "rel", Value: sig.Rel},
+		{Name: "role", Value: sig.Role},
+	} {
+		if attr.Value == "" {
+			continue
+		}
+
+		schema.Properties.Set(attr.Name, &jsonschema.Schema{
+			Const: attr.Value,
+		})
+		schema.Required = append(schema.Required, attr.Name)
+	}
+
+	return &schema
+}
+
+func (g *schemaGenerator) blockSchema(spec *BlockSpec) *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Type:                 "object",
+		Title:                spec.Name,
+		Description:          spec.Description,
+		Deprecated:           spec.Deprecated != nil,
+		Properties:           jsonschema.NewProperties(),
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+
+	sigSchema := signatureSchema(spec.Declares)
+
+	schema.Required = sigSchema.Required
+
+	// Conditional extensions can declare attributes and data that aren't
+	// part of the base declaration, allow them here and let the
+	// conditional schemas constrain them.
+	attributes := spec.Attributes
+	data := spec.Data
+
+	for _, cond := range spec.Conditional {
+		attributes = mergeConstraintMaps(attributes, optionalConstraints(cond.Attributes))
+		data = mergeConstraintMaps(data, optionalConstraints(cond.Data))
+	}
+
+	for _, k := range allBlockAttributes {
+		name := string(k)
+
+		if s, ok := sigSchema.Properties.Get(name); ok {
+			s.Type = "string"
+			schema.Properties.Set(name, s)
+
+			continue
+		}
+
+		c, declared := attributes.Constraints[name]
+
+		switch {
+		case !declared:
+			schema.Properties.Set(name, &jsonschema.Schema{
+				Ref: "#/$defs/empty",
+			})
+		default:
+			schema.Properties.Set(name, g.stringSchema(c))
+
+			if !c.Optional && !c.AllowEmpty {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	schema.Properties.Set(string(blockAttrID), &jsonschema.Schema{
+		Type: "string",
+	})
+
+	dataSchema := g.dataSchema(data)
+
+	schema.Properties.Set("data", dataSchema)
+
+	if len(dataSchema.Required) > 0 {
+		schema.Required = append(schema.Required, "data")
+	}
+
+	g.setBlockLists(&schema, spec.Blocks, func(kind BlockKind) []*BlockSpec {
+		var blocks []*BlockSpec
+
+		for _, cond := range spec.Conditional {
+			blocks = append(blocks, cond.Blocks(kind)...)
+		}
+
+		return blocks
+	})
+
+	for _, cond := range spec.Conditional {
+		condition := jsonschema.Schema{
+			Properties: jsonschema.NewProperties(),
+		}
+
+		for _, k := range cond.Match.Keys {
+			c := cond.Match.Constraints[k]
+
+			condition.Properties.Set(k, g.stringSchema(c))
+			condition.Required = append(condition.Required, k)
+		}
+
+		then := jsonschema.Schema{
+			Properties: jsonschema.NewProperties(),
+		}
+
+		for _, k := range cond.Attributes.Keys {
+			c := cond.Attributes.Constraints[k]
+
+			then.Properties.Set(k, g.stringSchema(c))
+
+			if !c.Optional && !c.AllowEmpty {
+				then.Required = append(then.Required, k)
+			}
+		}
+
+		if len(cond.Data.Keys) > 0 {
+			condData := g.dataSchema(cond.Data)
+
+			condData.AdditionalProperties = nil
+
+			then.Properties.Set("data", condData)
+
+			if len(condData.Required) > 0 {
+				then.Required = append(then.Required, "data")
+			}
+		}
+
+		for _, kind := range blockKinds {
+			blocks := cond.Blocks(kind)
+			if len(blocks) == 0 {
+				continue
+			}
+
+			// The items are checked by the base schema, only add
+			// the count checks here.
+			list := g.blockListSchema(blocks, nil)
+
+			list.Items = nil
+
+			then.Properties.Set(kindFields[kind], list)
+
+			if slices.ContainsFunc(blocks, requiresBlock) {
+				then.Required = append(then.Required, kindFields[kind])
+			}
+		}
+
+		schema.AllOf = append(schema.AllOf, &jsonschema.Schema{
+			If:   &condition,
+			Then: &then,
+		})
+	}
+
+	return &schema
+}
+
+func optionalConstraints(cm ConstraintMap) ConstraintMap {
+	res := MakeConstraintMap(make(map[string]StringConstraint, len(cm.Keys)))
+
+	for _, k := range cm.Keys {
+		res.Keys = append(res.Keys, k)
+		res.Constraints[k] = StringConstraint{
+			Optional:   true,
+			AllowEmpty: true,
+		}
+	}
+
+	return res
+}
+
+func (g *schemaGenerator) dataSchema(cm ConstraintMap) *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Type:                 "object",
+		Properties:           jsonschema.NewProperties(),
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+
+	for _, k := range cm.Keys {
+		c := cm.Constraints[k]
+
+		schema.Properties.Set(k, g.stringSchema(c))
+
+		if !c.Optional {
+			schema.Required = append(schema.Required, k)
+		}
+	}
+
+	return &schema
+}
+
+// stringSchema maps a string constraint to a JSON schema.
+func (g *schemaGenerator) stringSchema(c StringConstraint) *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Type:        "string",
+		Title:       c.Name,
+		Description: c.Description,
+		Deprecated:  c.Deprecated != nil,
+	}
+
+	var (
+		notes []string
+		all   []*jsonschema.Schema
+	)
+
+	if c.Const != nil {
+		schema.Const = *c.Const
+	}
+
+	if len(c.Enum) > 0 {
+		for _, v := range c.Enum {
+			schema.Enum = append(schema.Enum, v)
+		}
+	}
+
+	if c.EnumRef != "" {
+		ref, ok := g.enumDef(c.EnumRef)
+		if ok {
+			all = append(all, &jsonschema.Schema{Ref: ref})
+		}
+	}
+
+	if c.Pattern != nil {
+		schema.Pattern = c.Pattern.String()
+	}
+
+	if len(c.Glob) > 0 {
+		var alternatives []*jsonschema.Schema
+
+		for _, glob := range c.Glob {
+			p, err := glob.regexpPattern()
+			if err != nil {
+				alternatives = nil
+
+				notes = append(notes, c.Glob.String())
+
+				break
+			}
+
+			alternatives = append(alternatives, &jsonschema.Schema{
+				Pattern: p,
+			})
+		}
+
+		switch len(alternatives) {
+		case 0:
+		case 1:
+			all = append(all, alternatives[0])
+		default:
+			all = append(all, &jsonschema.Schema{AnyOf: alternatives})
+		}
+	}
+
+	if c.Time != "" {
+		notes = append(notes, fmt.Sprintf(
+			"must be a timestamp in the Go time layout %q", c.Time))
+	}
+
+	fs, note := g.formatSchema(c)
+	if fs != nil {
+		all = append(all, fs)
+	}
+
+	if note != "" {
+		notes = append(notes, note)
+	}
+
+	// Inline a single sub-schema when it can't conflict with the
+	// keywords that already have been set.
+	if len(all) == 1 && schema.Pattern == "" && len(schema.Enum) == 0 {
+		schema.Ref = all[0].Ref
+		schema.Pattern = all[0].Pattern
+		schema.Format = all[0].Format
+		schema.Enum = all[0].Enum
+		schema.AnyOf = all[0].AnyOf
+		schema.ContentMediaType = all[0].ContentMediaType
+		schema.ContentSchema = all[0].ContentSchema
+	} else if len(all) > 0 {
+		schema.AllOf = all
+	}
+
+	if len(notes) > 0 {
+		schema.Comments = "not expressed in this schema: " +
+			strings.Join(notes, "; ")
+	}
+
+	if c.AllowEmpty {
+		if schema.Const == nil && len(schema.Enum) == 0 &&
+			schema.Ref == "" && schema.Pattern == "" &&
+			schema.Format == "" && len(schema.AllOf) == 0 &&
+			len(schema.AnyOf) == 0 {
+			return &schema
+		}
+
+		inner := schema
+
+		inner.Title = ""
+		inner.Description = ""
+		inner.Deprecated = false
+
+		return &jsonschema.Schema{
+			Title:       schema.Title,
+			Description: schema.Description,
+			Deprecated:  schema.Deprecated,
+			AnyOf: []*jsonschema.Schema{
+				{Const: ""},
+				&inner,
+			},
+		}
+	}
+
+	schema.MinLength = uint64Ptr(1)
+
+	return &schema
+}
+
+// formatSchema returns the schema for the format of the constraint, and a
+// note describing the format if it can't be expressed in JSON schema.
+func (g *schemaGenerator) formatSchema(
+	c StringConstraint,
+) (*jsonschema.Schema, string) {
+	switch c.Format {
+	case StringFormatNone:
+		return nil, ""
+	case StringFormatRFC3339:
+		return &jsonschema.Schema{Format: "date-time"}, ""
+	case StringFormatInt:
+		return &jsonschema.Schema{Pattern: `^[+-]?[0-9]+$`}, ""
+	case StringFormatFloat:
+		return &jsonschema.Schema{
+			Pattern: `^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`,
+		}, ""
+	case StringFormatBoolean:
+		return &jsonschema.Schema{Enum: []any{
+			"1", "t", "T", "TRUE", "true", "True",
+			"0", "f", "F", "FALSE", "false", "False",
+		}}, ""
+	case StringFormatUUID:
+		return &jsonschema.Schema{Format: "uuid"}, ""
+	case StringFormatHTML:
+		policy := c.HTMLPolicy
+		if policy == "" {
+			policy = "default"
+		}
+
+		return nil, fmt.Sprintf("must be HTML following the %q policy", policy)
+	case StringFormatWKT:
+		return nil, geometryNote(c, "WKT")
+	case StringFormatGeoJSON:
+		return nil, geometryNote(c, "GeoJSON")
+	case StringFormatColour:
+		formats := c.ColourFormats
+		if len(formats) == 0 {
+			formats = defaultColourFormats
+		}
+
+		return nil, fmt.Sprintf("must be a colour in one of the formats %s",
+			quotedSlice(formats))
+	case StringFormatLanguage:
+		note := "must be a BCP 47 language tag"
+
+		if len(c.Languages) > 0 {
+			note += " for one of the languages " + quotedSlice(c.Languages)
+		}
+
+		if c.RequireRegion {
+			note += " with a region"
+		}
+
+		if c.Canonical {
+			note += " in canonical form"
+		}
+
+		return nil, note
+	case StringFormatURL:
+		return &jsonschema.Schema{Format: "uri"}, urlNote(c, true)
+	case StringFormatURI:
+		return &jsonschema.Schema{Format: "uri"}, urlNote(c, false)
+	case StringFormatDuration:
+		var limits []string
+
+		if c.MinDuration != nil {
+			limits = append(limits, "at least "+c.MinDuration.String())
+		}
+
+		if c.MaxDuration != nil {
+			limits = append(limits, "at most "+c.MaxDuration.String())
+		}
+
+		if len(limits) == 0 {
+			return nil, "must be an ISO 8601 duration"
+		}
+
+		return nil, "must be an ISO 8601 duration of " +
+			strings.Join(limits, " and ")
+	case StringFormatDate:
+		return &jsonschema.Schema{Format: "date"}, ""
+	case StringFormatTimeOfDay:
+		return &jsonschema.Schema{Pattern: timeOfDayExp.String()}, ""
+	case StringFormatCountry:
+		return &jsonschema.Schema{Pattern: `^[A-Z]{2}$`},
+			"must be " + c.Format.Describe()
+	case StringFormatCountryAlpha3, StringFormatCurrency:
+		return &jsonschema.Schema{Pattern: `^[A-Z]{3}$`},
+			"must be " + c.Format.Describe()
+	case StringFormatEmail:
+		return &jsonschema.Schema{Format: "email"}, ""
+	case StringFormatE164:
+		return &jsonschema.Schema{Pattern: e164Exp.String()}, ""
+	case StringFormatMIMEType:
+		if len(c.MIMETypes) > 0 {
+			return nil, "must be a MIME type that " + c.MIMETypes.String()
+		}
+
+		return nil, "must be a MIME type"
+	case StringFormatJSON:
+		schema := jsonschema.Schema{ContentMediaType: "application/json"}
+
+		if c.JSONSchema != nil {
+			schema.ContentSchema = c.JSONSchema.jsonSchema()
+		}
+
+		return &schema, ""
+	}
+
+	return nil, "must be " + g.v.formats.Describe(c.Format)
+}
+
+// geometryNote describes the geometry options of the constraint.
+func geometryNote(c StringConstraint, encoding string) string {
+	note := fmt.Sprintf("must be a %s geometry", encoding)
+
+	if c.Geometry != "" {
+		note = fmt.Sprintf("must be a %s %q geometry", encoding, c.Geometry)
+	}
+
+	var rules []string
+
+	if c.WGS84 {
+		rules = append(rules, "WGS84 coordinates")
+	}
+
+	if c.ValidRings {
+		rules = append(rules, "closed and non-intersecting polygon rings")
+	}
+
+	if c.MinPoints > 0 {
+		rules = append(rules, fmt.Sprintf("at least %d points", c.MinPoints))
+	}
+
+	if c.BoundingBox != nil {
+		rules = append(rules, "points within "+c.BoundingBox.String())
+	}
+
+	if len(rules) > 0 {
+		note += " with " + strings.Join(rules, ", ")
+	}
+
+	return note
+}
+
+// urlNote describes the URL options of the constraint that can't be expressed
+// in JSON schema.
+func urlNote(c StringConstraint, requireHost bool) string {
+	var rules []string
+
+	if requireHost {
+		rules = append(rules, "must have a host")
+	}
+
+	if len(c.Schemes) > 0 {
+		rules = append(rules, "the scheme must be one of "+quotedSlice(c.Schemes))
+	}
+
+	if len(c.Hosts) > 0 {
+		rules = append(rules, "the host "+c.Hosts.String())
+	}
+
+	if !c.AllowQuery {
+		rules = append(rules, "a query is not allowed")
+	}
+
+	if !c.AllowFragment {
+		rules = append(rules, "a fragment is not allowed")
+	}
+
+	return strings.Join(rules, ", ")
+}
+
+// enumDef adds a definition for the enum and returns a reference to it.
+func (g *schemaGenerator) enumDef(id string) (string, bool) {
+	name := "enum-" + schemaDefName(id)
+	ref := "#/$defs/" + name
+
+	_, exists := g.defs[name]
+	if exists {
+		return ref, true
+	}
+
+	spec, ok := g.v.Enum(id)
+	if !ok {
+		return "", false
+	}
+
+	def := jsonschema.Schema{
+		Title:       spec.Name,
+		Description: spec.Description,
+	}
+
+	var deprecated []string
+
+	for _, v := range spec.Values {
+		if v.Forbidden {
+			continue
+		}
+
+		def.Enum = append(def.Enum, v.Value)
+
+		if v.Deprecated != nil {
+			deprecated = append(deprecated, v.Value)
+		}
+	}
+
+	if len(deprecated) > 0 {
+		def.Comments = "deprecated values: " + quotedSlice(deprecated)
+	}
+
+	g.defs[name] = &def
+
+	return ref, true
+}
+
+var schemaDefReplacer = strings.NewReplacer("/", "-", "#", "-", "~", "-")
+
+func schemaDefName(id string) string {
+	return schemaDefReplacer.Replace(id)
+}
+
+func uint64Ptr(n int) *uint64 {
+	v := uint64(max(n, 0)) //nolint:gosec
+
+	return &v
+}
@@ -0,0 +1,138 @@
+package revisor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+	"github.com/ttab/revisor/internal/revisorschemas"
+)
+
+func compileDocumentSchema(
+	t *testing.T, schema any,
+) *jsonschema.Schema {
+	t.Helper()
+
+	data, err := json.Marshal(schema)
+	mustf(t, err, "marshal schema")
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	mustf(t, err, "unmarshal schema")
+
+	compiler := jsonschema.NewCompiler()
+
+	compiler.AssertFormat()
+
+	err = compiler.AddResource("schema.json", doc)
+	mustf(t, err, "add schema resource")
+
+	compiled, err := compiler.Compile("schema.json")
+	mustf(t, err, "compile schema")
+
+	return compiled
+}
+
+func TestDocumentJSONSchema(t *testing.T) {
+	sets, err := revisor.DecodeConstraintSetsFS(revisorschemas.Files(),
+		"core.json", "core-planning.json", "tt.json", "tt-planning.json")
+	mustf(t, err, "decode constraint sets")
+
+	validator, err := revisor.NewValidator(sets...)
+	mustf(t, err, "create validator")
+
+	validator = validator.WithVariants(revisor.Variant{
+		Name: "template",
+	})
+
+	schema := compileDocumentSchema(t, validator.DocumentsJSONSchema())
+
+	paths, err := filepath.Glob("testdata/*.json")
+	mustf(t, err, "glob for documents")
+
+	ctx := context.Background()
+
+	for _, p := range paths {
+		t.Run(filepath.Base(p), func(t *testing.T) {
+			var document newsdoc.Document
+
+			err := internal.UnmarshalFile(p, &document)
+			mustf(t, err, "unmarshal document")
+
+			res, err := validator.ValidateDocument(ctx, &document)
+			mustf(t, err, "validate document")
+
+			data, err := os.ReadFile(p)
+			mustf(t, err, "read document")
+
+			inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+			mustf(t, err, "unmarshal document for schema validation")
+
+			schemaErr := schema.Validate(inst)
+
+			// The schema can't express everything that the
+			// validator checks, so we can only require that
+			// valid documents pass.
+			if len(res) == 0 && schemaErr != nil {
+				t.Fatalf("valid document rejected by schema: %v", schemaErr)
+			}
+		})
+	}
+}
+
+func TestDocumentJSONSchemaGolden(t *testing.T) {
+	regenerate := regenerateGoldenFiles()
+
+	testConstraints := decodeConstraintSets(t,
+		"testdata/constraints/transcript.json",
+	)
+
+	validator, err := revisor.NewValidator(testConstraints...)
+	mustf(t, err, "create validator")
+
+	schema, err := validator.DocumentJSONSchema("core/transcript")
+	mustf(t, err, "generate schema")
+
+	got, err := json.MarshalIndent(schema, "", "  ")
+	mustf(t, err, "marshal schema")
+
+	got = append(got, '\n')
+
+	goldenPath := "testdata/results-schema/transcript.json"
+
+	if regenerate {
+		err := os.WriteFile(goldenPath, got, 0o600)
+		mustf(t, err, "write golden reference file")
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	mustf(t, err, "read golden reference file")
+
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Fatalf("schema mismatch (-want +got):\n%s", diff)
+	}
+
+	compiled := compileDocumentSchema(t, schema)
+
+	data, err := os.ReadFile("testdata/transcript.json")
+	mustf(t, err, "read document")
+
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	mustf(t, err, "unmarshal document")
+
+	if compiled.Validate(inst) == nil {
+		t.Fatal("expected the invalid transcript to be rejected")
+	}
+
+	_, err = validator.DocumentJSONSchema("core/nonexistent")
+	if err == nil {
+		t.Fatal("expected an error for an undeclared document type")
+	}
+}
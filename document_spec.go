@@ -0,0 +1,555 @@
+package revisor
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ttab/newsdoc"
+)
+
+// DocumentSpec is the resolved specification of a declared document type. Block
+// references and match extensions from all the constraint sets of the validator
+// have been applied.
+type DocumentSpec struct {
+	Type        string
+	Name        string
+	Description string
+	Deprecated  *Deprecation
+	Attributes  ConstraintMap
+	Links       []*BlockSpec
+	Meta        []*BlockSpec
+	Content     []*BlockSpec
+
+	// VariantTypes are the document types of the configured variants of
+	// the document type, f.ex. "core/article#template".
+	VariantTypes []string
+}
+
+// Blocks returns the block specifications of the specified kind.
+func (ds DocumentSpec) Blocks(kind BlockKind) []*BlockSpec {
+	switch kind {
+	case BlockKindLink:
+		return ds.Links
+	case BlockKindMeta:
+		return ds.Meta
+	case BlockKindContent:
+		return ds.Content
+	}
+
+	return nil
+}
+
+// BlockSpec is the resolved specification of a declared block. Constraints
+// that always apply to the block have been merged into the spec, while
+// extensions that only apply when additional match conditions are met are
+// listed in Conditional.
+type BlockSpec struct {
+	Kind        BlockKind
+	Declares    BlockSignature
+	Name        string
+	Description string
+	Deprecated  *Deprecation
+	Count       *int
+	MinCount    *int
+	MaxCount    *int
+	Attributes  ConstraintMap
+	Data        ConstraintMap
+	Links       []*BlockSpec
+	Meta        []*BlockSpec
+	Content     []*BlockSpec
+
+	// Match is set for conditional extensions, and contains the
+	// conditions that must be met for the extension to apply.
+	Match       ConstraintMap
+	Conditional []*BlockSpec
+}
+
+// Blocks returns the block specifications of the specified kind.
+func (bs BlockSpec) Blocks(kind BlockKind) []*BlockSpec {
+	switch kind {
+	case BlockKindLink:
+		return bs.Links
+	case BlockKindMeta:
+		return bs.Meta
+	case BlockKindContent:
+		return bs.Content
+	}
+
+	return nil
+}
+
+func (bs *BlockSpec) setBlocks(kind BlockKind, blocks []*BlockSpec) {
+	switch kind {
+	case BlockKindLink:
+		bs.Links = blocks
+	case BlockKindMeta:
+		bs.Meta = blocks
+	case BlockKindContent:
+		bs.Content = blocks
+	}
+}
+
+// DocumentSpecs returns the resolved specifications of all declared document
+// types in declaration order.
+func (v *Validator) DocumentSpecs() []*DocumentSpec {
+	var specs []*DocumentSpec
+
+	for _, d := range v.documents {
+		if d.Declares == "" {
+			continue
+		}
+
+		spec, ok := v.DocumentSpec(d.Declares)
+		if !ok {
+			continue
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+// DocumentSpec returns the resolved specification of a document type.
+func (v *Validator) DocumentSpec(docType string) (*DocumentSpec, bool) {
+	var (
+		declared bool
+		sets     []BlockConstraintSet
+	)
+
+	spec := DocumentSpec{
+		Type:       docType,
+		Attributes: MakeConstraintMap(nil),
+	}
+
+	vCtx := v.specContext()
+	doc := newsdoc.Document{Type: docType}
+
+	for _, dc := range v.documents {
+		match := dc.Matches(&doc, &vCtx)
+		if match == NoMatch {
+			continue
+		}
+
+		if match == MatchDeclaration {
+			declared = true
+			spec.Name = dc.Name
+			spec.Description = dc.Description
+		}
+
+		if spec.Deprecated == nil {
+			spec.Deprecated = dc.Deprecated
+		}
+
+		spec.Attributes = mergeConstraintMaps(spec.Attributes,
+			attributeConstraints(dc.Attributes, false))
+		sets = append(sets, dc)
+	}
+
+	if !declared {
+		return nil, false
+	}
+
+	for _, variant := range v.variants {
+		t := docType + "#" + variant.Name
+
+		if resolveVariant(t, v.variants) == docType {
+			spec.VariantTypes = append(spec.VariantTypes, t)
+		}
+	}
+
+	spec.Links = resolveBlockSpecs(&vCtx, BlockKindLink, sets)
+	spec.Meta = resolveBlockSpecs(&vCtx, BlockKindMeta, sets)
+	spec.Content = resolveBlockSpecs(&vCtx, BlockKindContent, sets)
+
+	return &spec, true
+}
+
+// specContext returns a validation context that can be used when evaluating
+// match expressions outside of a document validation.
+func (v *Validator) specContext() ValidationContext {
+	return ValidationContext{
+		coll:         ValueDiscarder{},
+		variants:     v.variants,
+		formats:      v.formats,
+		ValidateHTML: v.validateHTML,
+		ValidateEnum: v.enums.ValidValue,
+	}
+}
+
+func resolveBlockSpecs(
+	vCtx *ValidationContext, kind BlockKind, sets []BlockConstraintSet,
+) []*BlockSpec {
+	var (
+		all   []*BlockConstraint
+		specs []*BlockSpec
+		index = make(map[BlockSignature]*BlockSpec)
+	)
+
+	for _, s := range sets {
+		all = append(all, s.BlockConstraints(kind)...)
+	}
+
+	childSets := make(map[*BlockSpec][]BlockConstraintSet)
+
+	for _, bc := range all {
+		if bc.Declares == nil {
+			continue
+		}
+
+		sig := *bc.Declares
+
+		spec, exists := index[sig]
+		if !exists {
+			spec = &BlockSpec{
+				Kind:        kind,
+				Declares:    sig,
+				Name:        bc.Name,
+				Description: bc.Description,
+				Attributes:  MakeConstraintMap(nil),
+				Data:        MakeConstraintMap(nil),
+			}
+
+			index[sig] = spec
+			specs = append(specs, spec)
+		}
+
+		spec.mergeConstraint(bc)
+
+		childSets[spec] = append(childSets[spec], bc)
+	}
+
+	for _, spec := range specs {
+		for _, bc := range all {
+			if bc.Declares != nil {
+				continue
+			}
+
+			applies, conditions := bc.appliesTo(vCtx, spec.Declares)
+			if !applies {
+				continue
+			}
+
+			if len(conditions.Keys) > 0 {
+				cond := BlockSpec{
+					Kind:        kind,
+					Declares:    spec.Declares,
+					Name:        bc.Name,
+					Description: bc.Description,
+					Attributes:  MakeConstraintMap(nil),
+					Data:        MakeConstraintMap(nil),
+					Match:       conditions,
+				}
+
+				cond.mergeConstraint(bc)
+				cond.resolveChildren(vCtx, []BlockConstraintSet{bc})
+
+				spec.Conditional = append(spec.Conditional, &cond)
+
+				continue
+			}
+
+			spec.mergeConstraint(bc)
+
+			childSets[spec] = append(childSets[spec], bc)
+		}
+
+		spec.resolveChildren(vCtx, childSets[spec])
+	}
+
+	return specs
+}
+
+func (bs *BlockSpec) resolveChildren(
+	vCtx *ValidationContext, sets []BlockConstraintSet,
+) {
+	for _, kind := range blockKinds {
+		bs.setBlocks(kind, resolveBlockSpecs(vCtx, kind, sets))
+	}
+}
+
+// mergeConstraint merges the attributes, data, counts and deprecation of a
+// block constraint into the spec.
+func (bs *BlockSpec) mergeConstraint(bc *BlockConstraint) {
+	if bs.Name == "" {
+		bs.Name = bc.Name
+	}
+
+	if bs.Description == "" {
+		bs.Description = bc.Description
+	}
+
+	if bs.Deprecated == nil {
+		bs.Deprecated = bc.Deprecated
+	}
+
+	if bc.Count != nil {
+		bs.Count = intPtrCopy(bc.Count)
+	}
+
+	if bc.MinCount != nil && (bs.MinCount == nil || *bc.MinCount > *bs.MinCount) {
+		bs.MinCount = intPtrCopy(bc.MinCount)
+	}
+
+	if bc.MaxCount != nil && (bs.MaxCount == nil || *bc.MaxCount < *bs.MaxCount) {
+		bs.MaxCount = intPtrCopy(bc.MaxCount)
+	}
+
+	bs.Attributes = mergeConstraintMaps(bs.Attributes,
+		attributeConstraints(bc.Attributes, true))
+	bs.Data = mergeConstraintMaps(bs.Data, bc.Data)
+}
+
+// appliesTo checks if the block constraint applies to blocks with the given
+// signature. Match expressions for attributes that aren't part of the
+// signature are returned as conditions.
+func (bc BlockConstraint) appliesTo(
+	vCtx *ValidationContext, sig BlockSignature,
+) (bool, ConstraintMap) {
+	conditions := make(map[string]StringConstraint)
+
+	for _, k := range bc.Match.Keys {
+		var value string
+
+		//nolint:exhaustive
+		switch blockAttributeKey(k) {
+		case blockAttrType:
+			value = sig.Type
+		case blockAttrRel:
+			value = sig.Rel
+		case blockAttrRole:
+			value = sig.Role
+		}
+
+		check := bc.Match.Constraints[k]
+
+		if value == "" {
+			conditions[k] = check
+
+			continue
+		}
+
+		check.AllowEmpty = check.AllowEmpty || check.Optional
+
+		_, err := check.Validate(value, true, vCtx)
+		if err != nil {
+			return false, ConstraintMap{}
+		}
+	}
+
+	return true, MakeConstraintMap(conditions)
+}
+
+// attributeConstraints normalises attribute constraints so that they describe
+// how they are validated. Attributes always exist, so they are never optional.
+// Optional block attributes are allowed to be empty, while optional has no
+// effect for document attributes.
+func attributeConstraints(cm ConstraintMap, optionalIsEmpty bool) ConstraintMap {
+	res := cm.Copy()
+
+	for k, c := range res.Constraints {
+		c.AllowEmpty = c.AllowEmpty || (optionalIsEmpty && c.Optional)
+		c.Optional = false
+
+		res.Constraints[k] = c
+	}
+
+	return res
+}
+
+// mergeConstraintMaps returns a new constraint map with the constraints of both
+// maps. Constraints for the same key are merged with mergeStringConstraints.
+func mergeConstraintMaps(a ConstraintMap, b ConstraintMap) ConstraintMap {
+	res := a.Copy()
+
+	for _, k := range b.Keys {
+		existing, ok := res.Constraints[k]
+		if !ok {
+			res.Keys = append(res.Keys, k)
+			res.Constraints[k] = b.Constraints[k]
+
+			continue
+		}
+
+		res.Constraints[k] = mergeStringConstraints(existing, b.Constraints[k])
+	}
+
+	slices.Sort(res.Keys)
+
+	return res
+}
+
+// mergeStringConstraints combines two constraints that apply to the same
+// value. A value has to satisfy both constraints, so the value is only
+// optional or allowed to be empty if both constraints allow it. Other
+// constraints are taken from the first constraint, with the second filling in
+// anything that's missing.
+func mergeStringConstraints(a StringConstraint, b StringConstraint) StringConstraint {
+	m := a
+
+	m.Optional = a.Optional && b.Optional
+	m.AllowEmpty = a.AllowEmpty && b.AllowEmpty
+
+	if m.Name == "" {
+		m.Name = b.Name
+	}
+
+	if m.Description == "" {
+		m.Description = b.Description
+	}
+
+	if m.Const == nil {
+		m.Const = b.Const
+	}
+
+	if len(m.Enum) == 0 {
+		m.Enum = b.Enum
+	}
+
+	if m.EnumRef == "" {
+		m.EnumRef = b.EnumRef
+	}
+
+	if m.Pattern == nil {
+		m.Pattern = b.Pattern
+	}
+
+	if len(m.Glob) == 0 {
+		m.Glob = b.Glob
+	}
+
+	if m.Format == StringFormatNone {
+		m.Format = b.Format
+		m.Geometry = b.Geometry
+		m.WGS84 = b.WGS84
+		m.ValidRings = b.ValidRings
+		m.MinPoints = b.MinPoints
+		m.BoundingBox = b.BoundingBox
+		m.ColourFormats = b.ColourFormats
+		m.NormaliseColour = b.NormaliseColour
+		m.Languages = b.Languages
+		m.RequireRegion = b.RequireRegion
+		m.Canonical = b.Canonical
+		m.Schemes = b.Schemes
+		m.Hosts = b.Hosts
+		m.AllowQuery = b.AllowQuery
+		m.AllowFragment = b.AllowFragment
+		m.MinDuration = b.MinDuration
+		m.MaxDuration = b.MaxDuration
+		m.MIMETypes = b.MIMETypes
+		m.JSONSchema = b.JSONSchema
+		m.HTMLPolicy = b.HTMLPolicy
+	}
+
+	if m.Time == "" {
+		m.Time = b.Time
+	}
+
+	if m.Deprecated == nil {
+		m.Deprecated = b.Deprecated
+	}
+
+	for _, l := range b.Labels {
+		if !slices.Contains(m.Labels, l) {
+			m.Labels = append(slices.Clip(m.Labels), l)
+		}
+	}
+
+	if len(b.Hints) > 0 {
+		hints := maps.Clone(m.Hints)
+		if hints == nil {
+			hints = make(map[string][]string)
+		}
+
+		for k, v := range b.Hints {
+			hints[k] = append(slices.Clip(hints[k]), v...)
+		}
+
+		m.Hints = hints
+	}
+
+	return m
+}
+
+// EnumSpec is the resolved specification of an enum, including the values
+// added by enum extensions.
+type EnumSpec struct {
+	ID          string
+	Name        string
+	Description string
+	Values      []EnumValueSpec
+}
+
+// EnumValueSpec describes an enum value.
+type EnumValueSpec struct {
+	Value       string
+	Description string
+	Forbidden   bool
+	Deprecated  *Deprecation
+}
+
+// Enums returns the resolved specifications of all declared enums.
+func (v *Validator) Enums() []EnumSpec {
+	ids := slices.Sorted(maps.Keys(v.enums.enums))
+	specs := make([]EnumSpec, 0, len(ids))
+
+	for _, id := range ids {
+		spec, _ := v.Enum(id)
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+// Enum returns the resolved specification of an enum.
+func (v *Validator) Enum(id string) (EnumSpec, bool) {
+	m, ok := v.enums.enums[id]
+	if !ok {
+		return EnumSpec{}, false
+	}
+
+	spec := EnumSpec{
+		ID:          id,
+		Name:        m.Name,
+		Description: m.Description,
+	}
+
+	for _, value := range slices.Sorted(maps.Keys(m.Values)) {
+		vs := EnumValueSpec{
+			Value: value,
+		}
+
+		for _, c := range m.Values[value] {
+			vs.Forbidden = vs.Forbidden || c.Forbidden
+
+			if vs.Deprecated == nil {
+				vs.Deprecated = c.Deprecated
+			}
+
+			if vs.Description == "" {
+				vs.Description = c.Description
+			}
+		}
+
+		spec.Values = append(spec.Values, vs)
+	}
+
+	return spec, true
+}
+
+// HTMLPolicies returns the resolved HTML policies, ordered by name.
+func (v *Validator) HTMLPolicies() []HTMLPolicy {
+	policies := make([]HTMLPolicy, 0, len(v.htmlPolicies))
+
+	for _, p := range v.htmlPolicies {
+		policies = append(policies, *p)
+	}
+
+	slices.SortFunc(policies, func(a, b HTMLPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return policies
+}
@@ -0,0 +1,126 @@
+package revisor_test
+
+import (
+	"testing"
+
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal/revisorschemas"
+)
+
+func findBlockSpec(
+	specs []*revisor.BlockSpec, sig revisor.BlockSignature,
+) *revisor.BlockSpec {
+	for _, s := range specs {
+		if s.Declares == sig {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func TestDocumentSpec(t *testing.T) {
+	sets, err := revisor.DecodeConstraintSetsFS(revisorschemas.Files(),
+		"core.json", "tt.json")
+	mustf(t, err, "decode constraint sets")
+
+	validator, err := revisor.NewValidator(sets...)
+	mustf(t, err, "create validator")
+
+	spec, ok := validator.DocumentSpec("core/article")
+	if !ok {
+		t.Fatal("expected a spec for core/article")
+	}
+
+	if spec.Name != "Article" {
+		t.Errorf("expected the name of the declaration, got %q", spec.Name)
+	}
+
+	newsvalue := findBlockSpec(spec.Meta, revisor.BlockSignature{
+		Type: "core/newsvalue",
+	})
+	if newsvalue == nil {
+		t.Fatal("expected the referenced newsvalue block to be resolved")
+	}
+
+	if newsvalue.Count == nil || *newsvalue.Count != 1 {
+		t.Error("expected the count of the ref constraint to be merged")
+	}
+
+	if _, ok := newsvalue.Attributes.Constraints["value"]; !ok {
+		t.Error("expected the attributes of the referenced block")
+	}
+
+	slugline := findBlockSpec(spec.Meta, revisor.BlockSignature{
+		Type: "tt/slugline",
+	})
+	if slugline == nil {
+		t.Error("expected the match extension from tt.json to add tt/slugline")
+	}
+
+	_, ok = validator.DocumentSpec("core/nonexistent")
+	if ok {
+		t.Error("expected no spec for an undeclared document type")
+	}
+
+	t.Run("Conditional", func(t *testing.T) {
+		geo, err := revisor.NewValidator(decodeConstraintSets(t,
+			"testdata/constraints/geo.json")...)
+		mustf(t, err, "create geo validator")
+
+		spec, ok := geo.DocumentSpec("core/place")
+		if !ok {
+			t.Fatal("expected a spec for core/place")
+		}
+
+		place := findBlockSpec(spec.Meta, revisor.BlockSignature{
+			Type: "core/place",
+		})
+		if place == nil {
+			t.Fatal("expected a core/place meta block")
+		}
+
+		if len(place.Conditional) != 1 {
+			t.Fatalf("expected one conditional extension, got %d",
+				len(place.Conditional))
+		}
+
+		cond := place.Conditional[0]
+
+		if _, ok := cond.Match.Constraints["role"]; !ok {
+			t.Error("expected the role match to be a condition")
+		}
+
+		if _, ok := cond.Match.Constraints["type"]; ok {
+			t.Error("the type match should be satisfied by the signature")
+		}
+	})
+}
+
+func TestEnumSpecs(t *testing.T) {
+	sets, err := revisor.DecodeConstraintSetsFS(revisorschemas.Files(),
+		"core.json", "tt.json")
+	mustf(t, err, "decode constraint sets")
+
+	validator, err := revisor.NewValidator(sets...)
+	mustf(t, err, "create validator")
+
+	spec, ok := validator.Enum("core/text-roles")
+	if !ok {
+		t.Fatal("expected the core/text-roles enum")
+	}
+
+	values := make(map[string]revisor.EnumValueSpec)
+
+	for _, v := range spec.Values {
+		values[v.Value] = v
+	}
+
+	if !values["heading-3"].Forbidden {
+		t.Error("expected heading-3 to be forbidden by the tt extension")
+	}
+
+	if _, ok := values["vignette"]; !ok {
+		t.Error("expected vignette to be added by the tt extension")
+	}
+}
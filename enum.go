@@ -22,8 +22,11 @@ type EnumConstraint struct {
 }
 
 type mergedEnum struct {
-	Values  map[string][]EnumConstraint
-	Allowed []string
+	Name        string
+	Description string
+	Values      map[string][]EnumConstraint
+	Sources     map[string][]specRef
+	Allowed     []string
 }
 
 func mergedEnumAllowedValues(m *mergedEnum) []string {
@@ -60,17 +63,22 @@ func mergedEnumAllowedValues(m *mergedEnum) []string {
 }
 
 type enumSet struct {
-	extensions []Enum
+	extensions []enumExtension
 	enums      map[string]*mergedEnum
 }
 
+type enumExtension struct {
+	Enum Enum
+	Spec specRef
+}
+
 func newEnumSet() *enumSet {
 	return &enumSet{
 		enums: make(map[string]*mergedEnum),
 	}
 }
 
-func (s *enumSet) Register(e Enum) error {
+func (s *enumSet) Register(spec specRef, e Enum) error {
 	if e.Declare != "" && e.Match != "" {
 		return fmt.Errorf(
 			"the enum %q cannot both declare and match an enum",
@@ -82,7 +90,10 @@ func (s *enumSet) Register(e Enum) error {
 	}
 
 	if e.Match != "" {
-		s.extensions = append(s.extensions, e)
+		s.extensions = append(s.extensions, enumExtension{
+			Enum: e,
+			Spec: spec,
+		})
 
 		return nil
 	}
@@ -94,11 +105,15 @@ func (s *enumSet) Register(e Enum) error {
 	}
 
 	m := mergedEnum{
-		Values: make(map[string][]EnumConstraint, len(e.Values)),
+		Name:        e.Name,
+		Description: e.Description,
+		Values:      make(map[string][]EnumConstraint, len(e.Values)),
+		Sources:     make(map[string][]specRef, len(e.Values)),
 	}
 
 	for k, c := range e.Values {
 		m.Values[k] = []EnumConstraint{c}
+		m.Sources[k] = []specRef{spec.child("values", k)}
 	}
 
 	s.enums[e.Declare] = &m
@@ -107,7 +122,9 @@ func (s *enumSet) Register(e Enum) error {
 }
 
 func (s *enumSet) Resolve() error {
-	for _, e := range s.extensions {
+	for _, ext := range s.extensions {
+		e := ext.Enum
+
 		m, declared := s.enums[e.Match]
 		if !declared {
 			return fmt.Errorf("the enum %q hasn't been declared and cannot be matched", e.Match)
@@ -115,6 +132,8 @@ func (s *enumSet) Resolve() error {
 
 		for k, c := range e.Values {
 			m.Values[k] = append(m.Values[k], c)
+			m.Sources[k] = append(m.Sources[k],
+				ext.Spec.child("values", k))
 		}
 	}
 
@@ -150,3 +169,14 @@ func (s *enumSet) ValidValue(enum string, value string) (*Deprecation, error) {
 
 	return deprecation, nil
 }
+
+// valueSources returns references to the enum declarations and extensions
+// that declared the value.
+func (s *enumSet) valueSources(enum string, value string) []specRef {
+	m, declared := s.enums[enum]
+	if !declared {
+		return nil
+	}
+
+	return m.Sources[value]
+}
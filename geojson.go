@@ -0,0 +1,474 @@
+package revisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IvanZagoskin/wkt/geometry"
+	"github.com/IvanZagoskin/wkt/parser"
+)
+
+// geoJSONGeometry is a GeoJSON geometry object.
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+func validateGeoJSON(value string, sc *StringConstraint) error {
+	geo, err := parseGeoJSON(value)
+	if err != nil {
+		return err
+	}
+
+	err = checkGeometryType(sc.Geometry, geo)
+	if err != nil {
+		return err
+	}
+
+	return checkGeometry(geo, sc)
+}
+
+// parseGeoJSON parses a GeoJSON geometry object into the same representation
+// as WKT geometries.
+func parseGeoJSON(value string) (geometry.Geometry, error) {
+	var g geoJSONGeometry
+
+	err := json.Unmarshal([]byte(value), &g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	if len(g.Coordinates) == 0 || bytes.Equal(g.Coordinates, []byte("null")) {
+		switch g.Type {
+		case "":
+			return nil, errors.New("missing geometry type")
+		case "GeometryCollection", "Feature", "FeatureCollection":
+			return nil, fmt.Errorf("unsupported GeoJSON type %q", g.Type)
+		}
+
+		return nil, errors.New("missing coordinates")
+	}
+
+	var p geoJSONParser
+
+	switch g.Type {
+	case "Point":
+		var c []float64
+
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err != nil {
+			break
+		}
+
+		return p.point(c)
+	case "MultiPoint":
+		var c [][]float64
+
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err != nil {
+			break
+		}
+
+		points, err := p.points(c)
+		if err != nil {
+			return nil, err
+		}
+
+		return &geometry.MultiPoint{Points: points, Type: p.ct}, nil
+	case "LineString":
+		var c [][]float64
+
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err != nil {
+			break
+		}
+
+		return p.lineString(c)
+	case "MultiLineString":
+		var c [][][]float64
+
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err != nil {
+			break
+		}
+
+		ml := geometry.MultiLineString{}
+
+		for _, lc := range c {
+			l, err := p.lineString(lc)
+			if err != nil {
+				return nil, err
+			}
+
+			ml.Lines = append(ml.Lines, l)
+		}
+
+		ml.Type = p.ct
+
+		return &ml, nil
+	case "Polygon":
+		var c [][][]float64
+
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err != nil {
+			break
+		}
+
+		return p.polygon(c)
+	case "MultiPolygon":
+		var c [][][][]float64
+
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err != nil {
+			break
+		}
+
+		mp := geometry.MultiPolygon{}
+
+		for _, pc := range c {
+			poly, err := p.polygon(pc)
+			if err != nil {
+				return nil, err
+			}
+
+			mp.Polygons = append(mp.Polygons, poly)
+		}
+
+		mp.Type = p.ct
+
+		return &mp, nil
+	default:
+		return nil, fmt.Errorf("unsupported GeoJSON type %q", g.Type)
+	}
+
+	return nil, fmt.Errorf("invalid %s coordinates: %w", g.Type, err)
+}
+
+// geoJSONParser keeps track of the coordinate type so that all positions of a
+// geometry have the same number of dimensions.
+type geoJSONParser struct {
+	ct geometry.CoordinateType
+}
+
+func (p *geoJSONParser) point(c []float64) (*geometry.Point, error) {
+	var ct geometry.CoordinateType
+
+	switch len(c) {
+	case 2:
+		ct = geometry.XY
+	case 3:
+		ct = geometry.XYZ
+	default:
+		return nil, fmt.Errorf(
+			"a position must have two or three values, got %d", len(c))
+	}
+
+	if p.ct != geometry.Undefined && p.ct != ct {
+		return nil, errors.New("all positions must have the same number of values")
+	}
+
+	p.ct = ct
+
+	point := geometry.Point{X: c[0], Y: c[1], Type: ct}
+
+	if ct == geometry.XYZ {
+		point.Z = c[2]
+	}
+
+	return &point, nil
+}
+
+func (p *geoJSONParser) points(c [][]float64) ([]*geometry.Point, error) {
+	points := make([]*geometry.Point, len(c))
+
+	for i := range c {
+		point, err := p.point(c[i])
+		if err != nil {
+			return nil, err
+		}
+
+		points[i] = point
+	}
+
+	return points, nil
+}
+
+func (p *geoJSONParser) lineString(c [][]float64) (*geometry.LineString, error) {
+	if len(c) < 2 {
+		return nil, errors.New("a linestring must have at least two positions")
+	}
+
+	points, err := p.points(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geometry.LineString{Points: points, Type: p.ct}, nil
+}
+
+func (p *geoJSONParser) polygon(c [][][]float64) (*geometry.Polygon, error) {
+	poly := geometry.Polygon{}
+
+	for _, rc := range c {
+		if len(rc) < 4 {
+			return nil, errors.New("a polygon ring must have at least four positions")
+		}
+
+		ring, err := p.points(rc)
+		if err != nil {
+			return nil, err
+		}
+
+		first, last := ring[0], ring[len(ring)-1]
+
+		if first.X != last.X || first.Y != last.Y || first.Z != last.Z {
+			return nil, errors.New(
+				"polygon ring is not closed, the first and last position must be the same")
+		}
+
+		poly.LineStrings = append(poly.LineStrings, &geometry.LineString{
+			Points: ring,
+			Type:   p.ct,
+		})
+	}
+
+	poly.Type = p.ct
+
+	return &poly, nil
+}
+
+// WKTToGeoJSON converts a WKT geometry to a GeoJSON geometry object. Geometries
+// with M coordinates and circular strings can't be represented in GeoJSON.
+func WKTToGeoJSON(value string) (string, error) {
+	geo, err := parser.New().ParseWKT(strings.NewReader(value))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse WKT: %w", err)
+	}
+
+	coordinates, err := geoJSONCoordinates(geo)
+	if err != nil {
+		return "", err
+	}
+
+	var typeName string
+
+	switch geo.(type) {
+	case *geometry.Point:
+		typeName = "Point"
+	case *geometry.MultiPoint:
+		typeName = "MultiPoint"
+	case *geometry.LineString:
+		typeName = "LineString"
+	case *geometry.MultiLineString:
+		typeName = "MultiLineString"
+	case *geometry.Polygon:
+		typeName = "Polygon"
+	case *geometry.MultiPolygon:
+		typeName = "MultiPolygon"
+	}
+
+	data, err := json.Marshal(struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}{
+		Type:        typeName,
+		Coordinates: coordinates,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal GeoJSON: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func geoJSONCoordinates(geo geometry.Geometry) (any, error) {
+	position := func(p *geometry.Point) ([]float64, error) {
+		switch p.Type {
+		case geometry.XY:
+			return []float64{p.X, p.Y}, nil
+		case geometry.XYZ:
+			return []float64{p.X, p.Y, p.Z}, nil
+		case geometry.Empty:
+			return nil, errors.New("empty geometries can't be converted to GeoJSON")
+		case geometry.Undefined, geometry.XYM, geometry.XYZM:
+		}
+
+		return nil, errors.New("M coordinates can't be converted to GeoJSON")
+	}
+
+	positions := func(points []*geometry.Point) ([][]float64, error) {
+		c := make([][]float64, len(points))
+
+		for i := range points {
+			pos, err := position(points[i])
+			if err != nil {
+				return nil, err
+			}
+
+			c[i] = pos
+		}
+
+		return c, nil
+	}
+
+	switch g := geo.(type) {
+	case *geometry.Point:
+		return position(g)
+	case *geometry.MultiPoint:
+		return positions(g.Points)
+	case *geometry.LineString:
+		return positions(g.Points)
+	case *geometry.MultiLineString:
+		c := make([][][]float64, len(g.Lines))
+
+		for i, l := range g.Lines {
+			pos, err := positions(l.Points)
+			if err != nil {
+				return nil, err
+			}
+
+			c[i] = pos
+		}
+
+		return c, nil
+	case *geometry.Polygon:
+		c := make([][][]float64, len(g.LineStrings))
+
+		for i, l := range g.LineStrings {
+			pos, err := positions(l.Points)
+			if err != nil {
+				return nil, err
+			}
+
+			c[i] = pos
+		}
+
+		return c, nil
+	case *geometry.MultiPolygon:
+		c := make([]any, len(g.Polygons))
+
+		for i, p := range g.Polygons {
+			pos, err := geoJSONCoordinates(p)
+			if err != nil {
+				return nil, err
+			}
+
+			c[i] = pos
+		}
+
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("%T geometries can't be converted to GeoJSON", geo)
+}
+
+// GeoJSONToWKT converts a GeoJSON geometry object to WKT.
+func GeoJSONToWKT(value string) (string, error) {
+	geo, err := parseGeoJSON(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	var (
+		b        strings.Builder
+		typeName string
+		ct       geometry.CoordinateType
+	)
+
+	switch g := geo.(type) {
+	case *geometry.Point:
+		typeName, ct = "POINT", g.Type
+	case *geometry.MultiPoint:
+		typeName, ct = "MULTIPOINT", g.Type
+	case *geometry.LineString:
+		typeName, ct = "LINESTRING", g.Type
+	case *geometry.MultiLineString:
+		typeName, ct = "MULTILINESTRING", g.Type
+	case *geometry.Polygon:
+		typeName, ct = "POLYGON", g.Type
+	case *geometry.MultiPolygon:
+		typeName, ct = "MULTIPOLYGON", g.Type
+	}
+
+	b.WriteString(typeName)
+
+	if ct == geometry.XYZ {
+		b.WriteString(" Z")
+	}
+
+	b.WriteString(" ")
+
+	writeWKTCoordinates(&b, geo)
+
+	return b.String(), nil
+}
+
+func writeWKTCoordinates(b *strings.Builder, geo geometry.Geometry) {
+	position := func(p *geometry.Point) {
+		b.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+		b.WriteString(" ")
+		b.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+
+		if p.Type == geometry.XYZ {
+			b.WriteString(" ")
+			b.WriteString(strconv.FormatFloat(p.Z, 'f', -1, 64))
+		}
+	}
+
+	positions := func(points []*geometry.Point) {
+		b.WriteString("(")
+
+		for i, p := range points {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			position(p)
+		}
+
+		b.WriteString(")")
+	}
+
+	lines := func(lines []*geometry.LineString) {
+		b.WriteString("(")
+
+		for i, l := range lines {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			positions(l.Points)
+		}
+
+		b.WriteString(")")
+	}
+
+	switch g := geo.(type) {
+	case *geometry.Point:
+		positions([]*geometry.Point{g})
+	case *geometry.MultiPoint:
+		positions(g.Points)
+	case *geometry.LineString:
+		positions(g.Points)
+	case *geometry.MultiLineString:
+		lines(g.Lines)
+	case *geometry.Polygon:
+		lines(g.LineStrings)
+	case *geometry.MultiPolygon:
+		b.WriteString("(")
+
+		for i, p := range g.Polygons {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			lines(p.LineStrings)
+		}
+
+		b.WriteString(")")
+	}
+}
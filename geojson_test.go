@@ -0,0 +1,51 @@
+package revisor_test
+
+import (
+	"testing"
+
+	"github.com/ttab/revisor"
+)
+
+func TestWKTGeoJSONConversion(t *testing.T) {
+	cases := map[string]string{
+		"POINT (18.07 59.33)":                              `{"type":"Point","coordinates":[18.07,59.33]}`,
+		"POINT Z (18.07 59.33 12)":                         `{"type":"Point","coordinates":[18.07,59.33,12]}`,
+		"MULTIPOINT (1 2, 3 4)":                            `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`,
+		"LINESTRING (30 10, 10 30, 40 40)":                 `{"type":"LineString","coordinates":[[30,10],[10,30],[40,40]]}`,
+		"MULTILINESTRING ((10 10, 20 20), (40 40, 30 30))": `{"type":"MultiLineString","coordinates":[[[10,10],[20,20]],[[40,40],[30,30]]]}`,
+		"POLYGON ((30 10, 40 40, 20 40, 10 20, 30 10), (20 30, 35 35, 30 20, 20 30))": `{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]],[[20,30],[35,35],[30,20],[20,30]]]}`,
+		"MULTIPOLYGON (((30 20, 45 40, 10 40, 30 20)), ((15 5, 40 10, 10 20, 15 5)))": `{"type":"MultiPolygon","coordinates":[[[[30,20],[45,40],[10,40],[30,20]]],[[[15,5],[40,10],[10,20],[15,5]]]]}`,
+	}
+
+	for wkt, geoJSON := range cases {
+		t.Run(wkt, func(t *testing.T) {
+			gotJSON, err := revisor.WKTToGeoJSON(wkt)
+			if err != nil {
+				t.Fatalf("convert WKT to GeoJSON: %v", err)
+			}
+
+			if gotJSON != geoJSON {
+				t.Errorf("expected GeoJSON %s, got %s", geoJSON, gotJSON)
+			}
+
+			gotWKT, err := revisor.GeoJSONToWKT(geoJSON)
+			if err != nil {
+				t.Fatalf("convert GeoJSON to WKT: %v", err)
+			}
+
+			if gotWKT != wkt {
+				t.Errorf("expected WKT %q, got %q", wkt, gotWKT)
+			}
+		})
+	}
+
+	for _, wkt := range []string{
+		"POINT M (1 2 3)",
+		"CIRCULARSTRING (0 0, 1 1, 1 0)",
+	} {
+		_, err := revisor.WKTToGeoJSON(wkt)
+		if err == nil {
+			t.Errorf("expected %q to fail conversion to GeoJSON", wkt)
+		}
+	}
+}
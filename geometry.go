@@ -0,0 +1,304 @@
+package revisor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/IvanZagoskin/wkt/geometry"
+	"github.com/invopop/jsonschema"
+)
+
+// BoundingBox is an area given as [minX, minY, maxX, maxY], f.ex.
+// [4.5, 54.5, 31.6, 71.2] for a longitude/latitude box around the Nordics.
+type BoundingBox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+func (bb BoundingBox) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "array",
+		Items: &jsonschema.Schema{
+			Type: "number",
+		},
+		MinItems:    uint64Ptr(4),
+		MaxItems:    uint64Ptr(4),
+		Description: "A bounding box given as [minX, minY, maxX, maxY]",
+	}
+}
+
+func (bb *BoundingBox) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{ //nolint:wrapcheck
+		bb.MinX, bb.MinY, bb.MaxX, bb.MaxY,
+	})
+}
+
+func (bb *BoundingBox) UnmarshalJSON(data []byte) error {
+	var box []float64
+
+	err := json.Unmarshal(data, &box)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(box) != 4 {
+		return fmt.Errorf(
+			"a bounding box must have four values, [minX, minY, maxX, maxY], got %d",
+			len(box))
+	}
+
+	if box[0] > box[2] || box[1] > box[3] {
+		return errors.New(
+			"the minimum values of a bounding box can't be larger than the maximum values")
+	}
+
+	*bb = BoundingBox{
+		MinX: box[0], MinY: box[1],
+		MaxX: box[2], MaxY: box[3],
+	}
+
+	return nil
+}
+
+// String returns a human readable representation of the bounding box.
+func (bb *BoundingBox) String() string {
+	return fmt.Sprintf("[%g, %g, %g, %g]", bb.MinX, bb.MinY, bb.MaxX, bb.MaxY)
+}
+
+// Contains checks if the point is inside the bounding box, points on the
+// edge are included.
+func (bb *BoundingBox) Contains(x, y float64) bool {
+	return x >= bb.MinX && x <= bb.MaxX && y >= bb.MinY && y <= bb.MaxY
+}
+
+// checkGeometry checks the geometry against the geometry options of the
+// constraint.
+func checkGeometry(geo geometry.Geometry, sc *StringConstraint) error {
+	points := geometryPoints(geo)
+
+	if sc.MinPoints > 0 && len(points) < sc.MinPoints {
+		return fmt.Errorf("expected at least %d points, got %d",
+			sc.MinPoints, len(points))
+	}
+
+	for _, p := range points {
+		if sc.WGS84 && (p.X < -180 || p.X > 180) {
+			return fmt.Errorf(
+				"longitude %g is out of range, must be between -180 and 180",
+				p.X)
+		}
+
+		if sc.WGS84 && (p.Y < -90 || p.Y > 90) {
+			return fmt.Errorf(
+				"latitude %g is out of range, must be between -90 and 90",
+				p.Y)
+		}
+
+		if sc.BoundingBox != nil && !sc.BoundingBox.Contains(p.X, p.Y) {
+			return fmt.Errorf("the point (%g %g) is outside of the bounding box %s",
+				p.X, p.Y, sc.BoundingBox)
+		}
+	}
+
+	if !sc.ValidRings {
+		return nil
+	}
+
+	for _, ring := range geometryRings(geo) {
+		err := checkRing(ring)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// geometryPoints returns all the points of a geometry.
+func geometryPoints(geo geometry.Geometry) []*geometry.Point {
+	switch g := geo.(type) {
+	case *geometry.Point:
+		return []*geometry.Point{g}
+	case *geometry.MultiPoint:
+		return g.Points
+	case *geometry.LineString:
+		return g.Points
+	case *geometry.CircularString:
+		return g.Points
+	case *geometry.MultiLineString:
+		var points []*geometry.Point
+
+		for _, l := range g.Lines {
+			points = append(points, l.Points...)
+		}
+
+		return points
+	case *geometry.Polygon:
+		var points []*geometry.Point
+
+		for _, l := range g.LineStrings {
+			points = append(points, l.Points...)
+		}
+
+		return points
+	case *geometry.MultiPolygon:
+		var points []*geometry.Point
+
+		for _, p := range g.Polygons {
+			points = append(points, geometryPoints(p)...)
+		}
+
+		return points
+	}
+
+	return nil
+}
+
+// geometryRings returns the rings of the polygons in a geometry.
+func geometryRings(geo geometry.Geometry) [][]*geometry.Point {
+	var rings [][]*geometry.Point
+
+	switch g := geo.(type) {
+	case *geometry.Polygon:
+		for _, l := range g.LineStrings {
+			rings = append(rings, l.Points)
+		}
+	case *geometry.MultiPolygon:
+		for _, p := range g.Polygons {
+			rings = append(rings, geometryRings(p)...)
+		}
+	}
+
+	return rings
+}
+
+type vec2 struct {
+	X, Y float64
+}
+
+// checkRing checks that a polygon ring is closed and doesn't intersect
+// itself.
+func checkRing(ring []*geometry.Point) error {
+	if len(ring) == 0 {
+		return nil
+	}
+
+	var points []vec2
+
+	// Repeated points are allowed, but would be treated as
+	// intersections.
+	for _, p := range ring {
+		v := vec2{p.X, p.Y}
+
+		if len(points) > 0 && points[len(points)-1] == v {
+			continue
+		}
+
+		points = append(points, v)
+	}
+
+	if points[0] != points[len(points)-1] {
+		return errors.New("polygon ring is not closed, the first and last point must be the same")
+	}
+
+	if len(points) < 4 {
+		return errors.New("polygon ring must have at least three distinct points")
+	}
+
+	segments := len(points) - 1
+
+	for i := range segments {
+		a, b := points[i], points[i+1]
+
+		for j := i + 1; j < segments; j++ {
+			c, d := points[j], points[j+1]
+
+			// Adjacent segments share a point, so they only
+			// intersect if the ring doubles back on itself.
+			adjacent := j == i+1 || (i == 0 && j == segments-1)
+
+			if adjacent {
+				if backtracks(a, b, c, d) {
+					p := shared(a, b, c, d)
+
+					return fmt.Errorf(
+						"polygon ring doubles back on itself at (%g %g)",
+						p.X, p.Y)
+				}
+
+				continue
+			}
+
+			if segmentsIntersect(a, b, c, d) {
+				return fmt.Errorf(
+					"polygon ring intersects itself between (%g %g)-(%g %g) and (%g %g)-(%g %g)",
+					a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y)
+			}
+		}
+	}
+
+	return nil
+}
+
+// shared returns the point that the adjacent segments a-b and c-d share.
+func shared(a, b, c, d vec2) vec2 {
+	if b == c {
+		return b
+	}
+
+	return a
+}
+
+// backtracks checks if the adjacent segments a-b and c-d overlap, that is,
+// that the second segment goes back along the first.
+func backtracks(a, b, c, d vec2) bool {
+	// Make p the shared point, and q and r the other ends.
+	p, q, r := b, a, d
+	if b != c {
+		p, q, r = a, b, c
+	}
+
+	if orientation(q, p, r) != 0 {
+		return false
+	}
+
+	return (q.X-p.X)*(r.X-p.X)+(q.Y-p.Y)*(r.Y-p.Y) > 0
+}
+
+// orientation returns 1 for a counter-clockwise turn, -1 for a clockwise turn,
+// and 0 if the points are collinear.
+func orientation(a, b, c vec2) int {
+	v := (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+
+	return 0
+}
+
+// onSegment checks if the collinear point c lies on the segment a-b.
+func onSegment(a, b, c vec2) bool {
+	return min(a.X, b.X) <= c.X && c.X <= max(a.X, b.X) &&
+		min(a.Y, b.Y) <= c.Y && c.Y <= max(a.Y, b.Y)
+}
+
+// segmentsIntersect checks if the segments a-b and c-d touch or intersect.
+func segmentsIntersect(a, b, c, d vec2) bool {
+	o1 := orientation(a, b, c)
+	o2 := orientation(a, b, d)
+	o3 := orientation(c, d, a)
+	o4 := orientation(c, d, b)
+
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+
+	return (o1 == 0 && onSegment(a, b, c)) ||
+		(o2 == 0 && onSegment(a, b, d)) ||
+		(o3 == 0 && onSegment(c, d, a)) ||
+		(o4 == 0 && onSegment(c, d, b))
+}
@@ -0,0 +1,17 @@
+package revisor_test
+
+import "testing"
+
+func TestBoundingBoxDecoding(t *testing.T) {
+	assertDecodeError(t, `{
+  "name": "x",
+  "documents": [
+    {
+      "declares": "test/doc",
+      "attributes": {
+        "title": {"format": "wkt", "boundingBox": [4.5, 54.5, 31.6]}
+      }
+    }
+  ]
+}`, "a bounding box must have four values")
+}
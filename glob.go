@@ -2,7 +2,9 @@ package revisor
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -103,3 +105,119 @@ func (gl GlobList) String() string {
 
 	return "must match one of " + strings.Join(patterns, ", ")
 }
+
+// regexpPattern translates the glob pattern to an equivalent anchored regular
+// expression.
+func (g *Glob) regexpPattern() (string, error) {
+	var s strings.Builder
+
+	s.WriteString("^")
+
+	rest, err := globToRegexp(&s, g.pattern, false)
+	if err != nil {
+		return "", err
+	}
+
+	if rest != "" {
+		return "", fmt.Errorf("unexpected %q in glob pattern", rest)
+	}
+
+	s.WriteString("$")
+
+	return s.String(), nil
+}
+
+const globSeparators = `/+`
+
+// globToRegexp writes the regular expression equivalent of the glob pattern to
+// s. If inAlt is true the translation stops at the first unescaped "," or "}"
+// and the remainder of the pattern is returned.
+func globToRegexp(s *strings.Builder, pattern string, inAlt bool) (string, error) {
+	for len(pattern) > 0 {
+		c := pattern[0]
+
+		switch {
+		case inAlt && (c == ',' || c == '}'):
+			return pattern, nil
+		case strings.HasPrefix(pattern, "**"):
+			s.WriteString(".*")
+
+			pattern = pattern[2:]
+
+			continue
+		case c == '*':
+			s.WriteString("[^" + regexp.QuoteMeta(globSeparators) + "]*")
+		case c == '?':
+			s.WriteString("[^" + regexp.QuoteMeta(globSeparators) + "]")
+		case c == '\\':
+			if len(pattern) < 2 {
+				return "", errors.New("trailing escape character")
+			}
+
+			s.WriteString(regexp.QuoteMeta(pattern[1:2]))
+
+			pattern = pattern[2:]
+
+			continue
+		case c == '[':
+			end := strings.IndexByte(pattern, ']')
+			if end == -1 {
+				return "", errors.New("unclosed character class")
+			}
+
+			class := pattern[1:end]
+
+			s.WriteString("[")
+
+			if after, ok := strings.CutPrefix(class, "!"); ok {
+				s.WriteString("^")
+
+				class = after
+			}
+
+			s.WriteString(strings.ReplaceAll(class, `\`, `\\`))
+			s.WriteString("]")
+
+			pattern = pattern[end+1:]
+
+			continue
+		case c == '{':
+			s.WriteString("(?:")
+
+			rest := pattern[1:]
+
+			for {
+				var err error
+
+				rest, err = globToRegexp(s, rest, true)
+				if err != nil {
+					return "", err
+				}
+
+				if rest == "" {
+					return "", errors.New("unclosed alternatives")
+				}
+
+				if rest[0] == '}' {
+					break
+				}
+
+				s.WriteString("|")
+
+				rest = rest[1:]
+			}
+
+			s.WriteString(")")
+
+			pattern = rest[1:]
+
+			continue
+		default:
+			s.WriteString(regexp.QuoteMeta(pattern[0:1]))
+		}
+
+		pattern = pattern[1:]
+	}
+
+	return "", nil
+}
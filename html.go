@@ -14,6 +14,9 @@ import (
 // can have.
 type HTMLPolicy struct {
 	ref string
+	// deprecations is set if any of the attributes of the policy can be
+	// deprecated.
+	deprecations bool
 
 	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
@@ -36,20 +39,48 @@ var (
 	nlSlice      = []byte{nl}
 )
 
+// HTMLAttributeDeprecation describes a deprecated HTML attribute, or
+// attribute value, that was used in a HTML value.
+type HTMLAttributeDeprecation struct {
+	Element     string
+	Attribute   string
+	Value       string
+	Deprecation Deprecation
+}
+
 // Check that the given value follows the constraints of the policy.
 func (hp *HTMLPolicy) Check(v string) error {
+	_, err := hp.check(v, nil)
+
+	return err
+}
+
+// CheckDeprecations checks that the given value follows the constraints of
+// the policy, and returns the deprecated attributes and attribute values
+// that it uses. The validation context is used to validate enum references
+// and nested HTML, and can be nil.
+func (hp *HTMLPolicy) CheckDeprecations(
+	v string, vCtx *ValidationContext,
+) ([]HTMLAttributeDeprecation, error) {
+	return hp.check(v, vCtx)
+}
+
+func (hp *HTMLPolicy) check(
+	v string, vCtx *ValidationContext,
+) ([]HTMLAttributeDeprecation, error) {
 	z := html.NewTokenizer(strings.NewReader(v))
 
 	var (
 		line     = 1
 		char     int
 		tagStack []string
+		depr     []HTMLAttributeDeprecation
 	)
 
 	var err error
 
 	for {
-		tagStack, err = hp.handleToken(z, tagStack)
+		tagStack, err = hp.handleToken(z, tagStack, vCtx, &depr)
 		if err != nil {
 			break
 		}
@@ -65,17 +96,20 @@ func (hp *HTMLPolicy) Check(v string) error {
 	}
 
 	if err != nil && !errors.Is(err, io.EOF) {
-		return fmt.Errorf("invalid html after line %d char %d: %w", line, char, err)
+		return nil, fmt.Errorf("invalid html after line %d char %d: %w", line, char, err)
 	}
 
 	if len(tagStack) > 0 {
-		return fmt.Errorf("unclosed tag <%s>", tagStack[0])
+		return nil, fmt.Errorf("unclosed tag <%s>", tagStack[0])
 	}
 
-	return nil
+	return depr, nil
 }
 
-func (hp *HTMLPolicy) handleToken(z *html.Tokenizer, tagStack []string) ([]string, error) {
+func (hp *HTMLPolicy) handleToken(
+	z *html.Tokenizer, tagStack []string,
+	vCtx *ValidationContext, depr *[]HTMLAttributeDeprecation,
+) ([]string, error) {
 	tt := z.Next()
 	switch tt {
 	case html.CommentToken:
@@ -116,8 +150,7 @@ func (hp *HTMLPolicy) handleToken(z *html.Tokenizer, tagStack []string) ([]strin
 				)
 			}
 
-			// TODO: Handle deprecation of HTML attribute values.
-			_, err := constraint.Validate(string(v), true, nil)
+			valueDepr, err := constraint.Validate(string(v), true, vCtx)
 			if err != nil {
 				return nil, fmt.Errorf(
 					"<%s> attribute %q: %w",
@@ -125,6 +158,19 @@ func (hp *HTMLPolicy) handleToken(z *html.Tokenizer, tagStack []string) ([]strin
 				)
 			}
 
+			for _, d := range []*Deprecation{constraint.Deprecated, valueDepr} {
+				if d == nil {
+					continue
+				}
+
+				*depr = append(*depr, HTMLAttributeDeprecation{
+					Element:     name,
+					Attribute:   attrName,
+					Value:       string(v),
+					Deprecation: *d,
+				})
+			}
+
 			attrs[attrName] = true
 
 			hasAttr = more
@@ -277,9 +323,27 @@ func (s *HTMLPolicySet) Resolve() (map[string]*HTMLPolicy, error) {
 		}
 	}
 
+	for _, p := range s.namedPolicies {
+		p.deprecations = policyHasDeprecations(p)
+	}
+
 	return s.namedPolicies, nil
 }
 
+// policyHasDeprecations checks if any of the attributes of the policy are
+// deprecated, or could have deprecated values.
+func policyHasDeprecations(p *HTMLPolicy) bool {
+	for _, e := range p.Elements {
+		for _, c := range e.Attributes.Constraints {
+			if c.Deprecated != nil || c.EnumRef != "" || c.Format == StringFormatHTML {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func extendHTMLPolicy(extending *HTMLPolicy, policy HTMLPolicy) error {
 	for eName, eDef := range policy.Elements {
 		eCurrent := extending.Elements[eName]
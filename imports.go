@@ -0,0 +1,475 @@
+package revisor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ConstraintSetLoader loads constraint sets by name when imports are
+// resolved.
+type ConstraintSetLoader interface {
+	LoadConstraintSet(name string) (ConstraintSet, error)
+}
+
+// ConstraintSetLoaderFunc is a function that implements ConstraintSetLoader.
+type ConstraintSetLoaderFunc func(name string) (ConstraintSet, error)
+
+// LoadConstraintSet implements ConstraintSetLoader.
+func (fn ConstraintSetLoaderFunc) LoadConstraintSet(name string) (ConstraintSet, error) {
+	return fn(name)
+}
+
+// FSConstraintSetLoader returns a loader that loads the constraint set named
+// "core" from "core.json", "core.yaml" or "core.yml" in the filesystem.
+func FSConstraintSetLoader(fsys fs.FS) ConstraintSetLoader {
+	return ConstraintSetLoaderFunc(func(name string) (ConstraintSet, error) {
+		for _, ext := range []string{".json", ".yaml", ".yml"} {
+			filename := name + ext
+
+			_, err := fs.Stat(fsys, filename)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return ConstraintSet{}, fmt.Errorf(
+					"check for %q: %w", filename, err)
+			}
+
+			sets, err := LoadConstraintSets(fsys, filename)
+			if err != nil {
+				return ConstraintSet{}, err
+			}
+
+			return sets[0], nil
+		}
+
+		return ConstraintSet{}, fmt.Errorf(
+			"no constraint set file found for %q", name)
+	})
+}
+
+// ResolveImports loads the constraint sets that are imported by the given
+// sets, and the sets that they in turn import. The returned list contains
+// the given and loaded sets in dependency order, imported sets come before
+// the sets that import them.
+func ResolveImports(
+	loader ConstraintSetLoader, sets ...ConstraintSet,
+) ([]ConstraintSet, error) {
+	known := make(map[string]ConstraintSet, len(sets))
+
+	for _, cs := range sets {
+		if _, exists := known[cs.Name]; exists {
+			return nil, fmt.Errorf(
+				"constraint set %q was given more than once", cs.Name)
+		}
+
+		known[cs.Name] = cs
+	}
+
+	lookup := func(name string) (ConstraintSet, error) {
+		cs, ok := known[name]
+		if ok {
+			return cs, nil
+		}
+
+		if loader == nil {
+			return ConstraintSet{}, fmt.Errorf(
+				"no loader for the import %q", name)
+		}
+
+		cs, err := loader.LoadConstraintSet(name)
+		if err != nil {
+			return ConstraintSet{}, fmt.Errorf("load import %q: %w", name, err)
+		}
+
+		if cs.Name != name {
+			return ConstraintSet{}, fmt.Errorf(
+				"the import %q loaded a constraint set named %q",
+				name, cs.Name)
+		}
+
+		known[name] = cs
+
+		return cs, nil
+	}
+
+	var ordered []ConstraintSet
+
+	err := walkImports(sets, lookup, func(cs ConstraintSet) {
+		ordered = append(ordered, cs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// walkImports visits the constraint sets in dependency order, and returns an
+// error if the imports have a cycle.
+func walkImports(
+	sets []ConstraintSet,
+	lookup func(name string) (ConstraintSet, error),
+	visit func(cs ConstraintSet),
+) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	state := make(map[string]int)
+
+	var (
+		stack []string
+		walk  func(cs ConstraintSet) error
+	)
+
+	walk = func(cs ConstraintSet) error {
+		switch state[cs.Name] {
+		case done:
+			return nil
+		case visiting:
+			start := slices.Index(stack, cs.Name)
+			cycle := append(slices.Clone(stack[start:]), cs.Name)
+
+			return fmt.Errorf("import cycle: %s",
+				strings.Join(cycle, " -> "))
+		}
+
+		state[cs.Name] = visiting
+		stack = append(stack, cs.Name)
+
+		for _, name := range cs.Imports {
+			imported, err := lookup(name)
+			if err != nil {
+				return fmt.Errorf("constraint set %q: %w", cs.Name, err)
+			}
+
+			err = walk(imported)
+			if err != nil {
+				return err
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[cs.Name] = done
+
+		visit(cs)
+
+		return nil
+	}
+
+	for _, cs := range sets {
+		err := walk(cs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkImports verifies that the imports of the constraint sets have been
+// loaded and don't have cycles, and that the sets that declare imports only
+// reference documents, blocks, enums and HTML policies from themselves or
+// the sets they (transitively) import.
+func checkImports(constraints []ConstraintSet) error {
+	byName := make(map[string][]ConstraintSet)
+
+	for _, cs := range constraints {
+		byName[cs.Name] = append(byName[cs.Name], cs)
+	}
+
+	lookup := func(name string) (ConstraintSet, error) {
+		sets := byName[name]
+		if len(sets) == 0 {
+			return ConstraintSet{}, fmt.Errorf(
+				"the import %q hasn't been loaded", name)
+		}
+
+		// Treat sets that share a name as one set when it comes
+		// to imports.
+		merged := ConstraintSet{Name: name}
+
+		for _, cs := range sets {
+			merged.Imports = append(merged.Imports, cs.Imports...)
+		}
+
+		return merged, nil
+	}
+
+	err := walkImports(constraints, lookup, func(_ ConstraintSet) {})
+	if err != nil {
+		return err
+	}
+
+	decl := newDeclarationIndex(constraints)
+
+	for _, cs := range constraints {
+		if len(cs.Imports) == 0 {
+			continue
+		}
+
+		visible := make(map[string]bool)
+
+		_ = walkImports([]ConstraintSet{cs}, lookup, func(dep ConstraintSet) {
+			visible[dep.Name] = true
+		})
+
+		check := importChecker{
+			decl:    decl,
+			visible: visible,
+		}
+
+		err := check.constraintSet(cs)
+		if err != nil {
+			return fmt.Errorf("constraint set %q: %w", cs.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// declarationIndex keeps track of which constraint set that declared what.
+type declarationIndex struct {
+	documents map[string]string
+	blocks    map[BlockKind]map[string]string
+	enums     map[string]string
+	policies  map[string]string
+}
+
+func newDeclarationIndex(constraints []ConstraintSet) declarationIndex {
+	idx := declarationIndex{
+		documents: make(map[string]string),
+		blocks:    make(map[BlockKind]map[string]string),
+		enums:     make(map[string]string),
+		policies:  make(map[string]string),
+	}
+
+	for _, kind := range blockKinds {
+		idx.blocks[kind] = make(map[string]string)
+	}
+
+	for _, cs := range constraints {
+		for _, d := range cs.Documents {
+			if d.Declares != "" {
+				idx.documents[d.Declares] = cs.Name
+			}
+		}
+
+		for _, kind := range blockKinds {
+			for _, def := range cs.BlockDefinitions(kind) {
+				idx.blocks[kind][def.ID] = cs.Name
+			}
+		}
+
+		for _, e := range cs.Enums {
+			if e.Declare != "" {
+				idx.enums[e.Declare] = cs.Name
+			}
+		}
+
+		for _, p := range cs.HTMLPolicies {
+			if p.Name != "" {
+				idx.policies[p.Name] = cs.Name
+			}
+		}
+	}
+
+	return idx
+}
+
+type importChecker struct {
+	decl    declarationIndex
+	visible map[string]bool
+}
+
+// reference checks that a referenced declaration is visible to the set.
+// References to things that haven't been declared at all are left for the
+// regular validator setup to report.
+func (c importChecker) reference(
+	path string, what string, name string, declaredIn map[string]string,
+) error {
+	source, declared := declaredIn[name]
+	if !declared || c.visible[source] {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%s: references the %s %q from %q, which isn't imported",
+		path, what, name, source)
+}
+
+func (c importChecker) constraintSet(cs ConstraintSet) error {
+	for i, d := range cs.Documents {
+		path := "/documents/" + strconv.Itoa(i)
+
+		for _, docType := range matchedValues(d.Match, "type") {
+			err := c.reference(path+"/match", "document type",
+				docType, c.decl.documents)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := c.constraintMaps(path, map[string]ConstraintMap{
+			"match":      d.Match,
+			"attributes": d.Attributes,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = c.blockLists(path, &d)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, kind := range blockKinds {
+		for i, def := range cs.BlockDefinitions(kind) {
+			path := "/" + kindFields[kind] + "/" + strconv.Itoa(i) + "/block"
+
+			err := c.block(path, kind, &def.Block)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, e := range cs.Enums {
+		if e.Match == "" {
+			continue
+		}
+
+		err := c.reference("/enums/"+strconv.Itoa(i)+"/match", "enum",
+			e.Match, c.decl.enums)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, m := range cs.Migrations {
+		for j, e := range m.Enums {
+			err := c.reference(
+				"/migrations/"+strconv.Itoa(i)+"/enums/"+strconv.Itoa(j)+"/enum",
+				"enum", e.Enum, c.decl.enums)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, p := range cs.HTMLPolicies {
+		path := "/htmlPolicies/" + strconv.Itoa(i)
+
+		if p.Uses != "" {
+			err := c.reference(path+"/uses", "HTML policy",
+				p.Uses, c.decl.policies)
+			if err != nil {
+				return err
+			}
+		}
+
+		if p.Extends != "" {
+			err := c.reference(path+"/extends", "HTML policy",
+				p.Extends, c.decl.policies)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c importChecker) blockLists(path string, source BlockConstraintSet) error {
+	for _, kind := range blockKinds {
+		for i, b := range source.BlockConstraints(kind) {
+			err := c.block(
+				path+"/"+kindFields[kind]+"/"+strconv.Itoa(i),
+				kind, b)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c importChecker) block(path string, kind BlockKind, b *BlockConstraint) error {
+	if b.Ref != "" {
+		err := c.reference(path+"/ref", string(kind)+" block",
+			b.Ref, c.decl.blocks[kind])
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.constraintMaps(path, map[string]ConstraintMap{
+		"match":      b.Match,
+		"attributes": b.Attributes,
+		"data":       b.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.blockLists(path, b)
+}
+
+func (c importChecker) constraintMaps(path string, maps map[string]ConstraintMap) error {
+	fields := make([]string, 0, len(maps))
+
+	for field := range maps {
+		fields = append(fields, field)
+	}
+
+	slices.Sort(fields)
+
+	for _, field := range fields {
+		cm := maps[field]
+
+		for _, k := range cm.Keys {
+			sc := cm.Constraints[k]
+			p := path + "/" + field + "/" + jsonPointerEscaper.Replace(k)
+
+			if sc.EnumRef != "" {
+				err := c.reference(p+"/enumReference", "enum",
+					sc.EnumRef, c.decl.enums)
+				if err != nil {
+					return err
+				}
+			}
+
+			if sc.HTMLPolicy != "" {
+				err := c.reference(p+"/htmlPolicy", "HTML policy",
+					sc.HTMLPolicy, c.decl.policies)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchedValues returns the values that a match constraint for the key
+// matches, if they're listed explicitly.
+func matchedValues(match ConstraintMap, key string) []string {
+	sc, ok := match.Constraints[key]
+	if !ok {
+		return nil
+	}
+
+	if sc.Const != nil {
+		return []string{*sc.Const}
+	}
+
+	return sc.Enum
+}
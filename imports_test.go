@@ -0,0 +1,167 @@
+package revisor_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal/revisorschemas"
+)
+
+func TestResolveImports(t *testing.T) {
+	loader := revisor.FSConstraintSetLoader(revisorschemas.Files())
+
+	planning, err := loader.LoadConstraintSet("tt-planning")
+	mustf(t, err, "load tt-planning")
+
+	tt, err := loader.LoadConstraintSet("tt")
+	mustf(t, err, "load tt")
+
+	sets, err := revisor.ResolveImports(loader, planning, tt)
+	mustf(t, err, "resolve imports")
+
+	assertSetNames(t, sets, "core", "core-planning", "tt-planning", "tt")
+
+	_, err = revisor.NewValidator(sets...)
+	mustf(t, err, "create validator from resolved sets")
+
+	_, err = revisor.NewValidator(planning)
+	if err == nil || !strings.Contains(err.Error(), `the import "core-planning" hasn't been loaded`) {
+		t.Fatalf("expected an error for missing imports, got: %v", err)
+	}
+}
+
+func TestResolveImportsErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.json":     {Data: []byte(`{"name": "a", "imports": ["b"]}`)},
+		"b.yaml":     {Data: []byte("name: b\nimports: [c]\n")},
+		"c.json":     {Data: []byte(`{"name": "c", "imports": ["a"]}`)},
+		"wrong.json": {Data: []byte(`{"name": "right"}`)},
+	}
+
+	loader := revisor.FSConstraintSetLoader(fsys)
+
+	cases := map[string]struct {
+		Set   revisor.ConstraintSet
+		Error string
+	}{
+		"cycle": {
+			Set:   revisor.ConstraintSet{Name: "a", Imports: []string{"b"}},
+			Error: "import cycle: a -> b -> c -> a",
+		},
+		"missing": {
+			Set:   revisor.ConstraintSet{Name: "x", Imports: []string{"nope"}},
+			Error: `no constraint set file found for "nope"`,
+		},
+		"wrong name": {
+			Set:   revisor.ConstraintSet{Name: "x", Imports: []string{"wrong"}},
+			Error: `the import "wrong" loaded a constraint set named "right"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := revisor.ResolveImports(loader, tc.Set)
+			if err == nil || !strings.Contains(err.Error(), tc.Error) {
+				t.Fatalf("expected an error containing %q, got: %v",
+					tc.Error, err)
+			}
+		})
+	}
+}
+
+const importsBaseSet = `{
+  "name": "base",
+  "documents": [{"declares": "test/doc"}],
+  "meta": [{"id": "base://meta", "block": {"declares": {"type": "test/meta"}}}],
+  "enums": [{"declare": "test/enum", "values": {"a": {}}}],
+  "htmlPolicies": [{"name": "base", "elements": {"p": {}}}]
+}`
+
+func TestImportVisibility(t *testing.T) {
+	base, err := revisor.DecodeConstraintSet("base.json",
+		strings.NewReader(importsBaseSet))
+	mustf(t, err, "decode base set")
+
+	middle := revisor.ConstraintSet{
+		Name:    "middle",
+		Imports: []string{"base"},
+	}
+
+	cases := map[string]struct {
+		Source string
+		Error  string
+	}{
+		"enum reference": {
+			Source: `{"documents": [{"declares": "test/other",
+  "attributes": {"title": {"enumReference": "test/enum"}}}]}`,
+			Error: `/documents/0/attributes/title/enumReference: references the enum "test/enum" from "base"`,
+		},
+		"block reference": {
+			Source: `{"documents": [{"declares": "test/other",
+  "meta": [{"ref": "base://meta"}]}]}`,
+			Error: `/documents/0/meta/0/ref: references the meta block "base://meta" from "base"`,
+		},
+		"document match": {
+			Source: `{"documents": [{"match": {"type": {"const": "test/doc"}}}]}`,
+			Error:  `/documents/0/match: references the document type "test/doc" from "base"`,
+		},
+		"enum match": {
+			Source: `{"enums": [{"match": "test/enum", "values": {"b": {}}}]}`,
+			Error:  `/enums/0/match: references the enum "test/enum" from "base"`,
+		},
+		"html policy": {
+			Source: `{"htmlPolicies": [{"extends": "base", "elements": {"b": {}}}]}`,
+			Error:  `/htmlPolicies/0/extends: references the HTML policy "base" from "base"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			set, err := revisor.DecodeConstraintSet(name,
+				strings.NewReader(tc.Source))
+			mustf(t, err, "decode constraint set")
+
+			set.Name = "ext"
+
+			// Sets without imports can reference anything.
+			_, err = revisor.NewValidator(base, set)
+			mustf(t, err, "create validator without imports")
+
+			// Transitive imports are visible.
+			set.Imports = []string{"middle"}
+
+			_, err = revisor.NewValidator(base, middle, set)
+			mustf(t, err, "create validator with transitive import")
+
+			// Unrelated imports don't make the reference visible.
+			set.Imports = []string{"unrelated"}
+
+			_, err = revisor.NewValidator(base, set, revisor.ConstraintSet{
+				Name: "unrelated",
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.Error) {
+				t.Fatalf("expected an error containing %q, got: %v",
+					tc.Error, err)
+			}
+		})
+	}
+}
+
+func TestNewValidatorImportCycle(t *testing.T) {
+	_, err := revisor.NewValidator(
+		revisor.ConstraintSet{Name: "a", Imports: []string{"b"}},
+		revisor.ConstraintSet{Name: "b", Imports: []string{"a"}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "import cycle: a -> b -> a") {
+		t.Fatalf("expected an import cycle error, got: %v", err)
+	}
+
+	_, err = revisor.NewValidator(
+		revisor.ConstraintSet{Name: "a", Imports: []string{"a"}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "cannot import itself") {
+		t.Fatalf("expected a self import error, got: %v", err)
+	}
+}
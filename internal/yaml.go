@@ -0,0 +1,469 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourcePosition maps an offset in converted JSON data to the line and
+// column of the value in the YAML source.
+type SourcePosition struct {
+	Offset int64
+	Line   int
+	Column int
+}
+
+// YAMLToJSON converts a YAML document to compact JSON, preserving the order
+// of mapping keys. The returned positions are sorted by offset and can be
+// used to translate JSON decoding errors to positions in the YAML source.
+//
+// Anchors, aliases and merge keys are resolved. Duplicate mapping keys and
+// non-string keys are treated as errors.
+func YAMLToJSON(data []byte) ([]byte, []SourcePosition, error) {
+	doc, err := ParseYAML(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return NodeToJSON(doc)
+}
+
+// ParseYAML parses a single YAML document.
+func ParseYAML(data []byte) (*yaml.Node, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	var doc yaml.Node
+
+	err := dec.Decode(&doc)
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty YAML document")
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	var extra yaml.Node
+
+	err = dec.Decode(&extra)
+	if err == nil {
+		return nil, fmt.Errorf(
+			"line %d: only one YAML document is allowed", extra.Line)
+	} else if !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// NodeToJSON converts a YAML node to compact JSON, see YAMLToJSON.
+func NodeToJSON(n *yaml.Node) ([]byte, []SourcePosition, error) {
+	var c yamlConverter
+
+	err := c.value(n, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.out.Bytes(), c.positions, nil
+}
+
+const (
+	// maxYAMLDepth limits the nesting of the converted document, aliases
+	// included, so that recursion can't exhaust the stack.
+	maxYAMLDepth = 100
+	// maxYAMLNodes limits the number of nodes that are visited during
+	// conversion. Aliases are expanded, so a small document with nested
+	// aliases can expand to an exponential number of nodes.
+	maxYAMLNodes = 1 << 20
+	// maxYAMLOutput limits the size of the converted document, as aliases
+	// to large scalars can expand to a lot of data without visiting many
+	// nodes.
+	maxYAMLOutput = 64 << 20
+)
+
+type yamlConverter struct {
+	out       bytes.Buffer
+	positions []SourcePosition
+	nodes     int
+}
+
+// visit accounts for a node in the conversion limits.
+func (c *yamlConverter) visit(n *yaml.Node, depth int) error {
+	if depth > maxYAMLDepth {
+		return fmt.Errorf("line %d: YAML is nested too deeply", n.Line)
+	}
+
+	c.nodes++
+
+	if c.nodes > maxYAMLNodes || c.out.Len() > maxYAMLOutput {
+		return fmt.Errorf(
+			"line %d: YAML expands to too much data, check the use of aliases",
+			n.Line)
+	}
+
+	return nil
+}
+
+func (c *yamlConverter) mark(n *yaml.Node) {
+	c.positions = append(c.positions, SourcePosition{
+		Offset: int64(c.out.Len()),
+		Line:   n.Line,
+		Column: n.Column,
+	})
+}
+
+func (c *yamlConverter) value(n *yaml.Node, depth int) error {
+	err := c.visit(n, depth)
+	if err != nil {
+		return err
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return errors.New("empty YAML document")
+		}
+
+		return c.value(n.Content[0], depth)
+	case yaml.AliasNode:
+		return c.value(n.Alias, depth+1)
+	case yaml.MappingNode:
+		return c.mapping(n, depth)
+	case yaml.SequenceNode:
+		c.mark(n)
+		c.out.WriteByte('[')
+
+		for i, item := range n.Content {
+			if i > 0 {
+				c.out.WriteByte(',')
+			}
+
+			err := c.value(item, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		c.out.WriteByte(']')
+
+		return nil
+	case yaml.ScalarNode:
+		return c.scalar(n)
+	}
+
+	return fmt.Errorf("line %d: unsupported YAML node", n.Line)
+}
+
+type yamlEntry struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+func (c *yamlConverter) mapping(n *yaml.Node, depth int) error {
+	entries, err := c.mappingEntries(n, depth)
+	if err != nil {
+		return err
+	}
+
+	c.mark(n)
+	c.out.WriteByte('{')
+
+	for i, e := range entries {
+		if i > 0 {
+			c.out.WriteByte(',')
+		}
+
+		c.mark(e.key)
+
+		key, err := json.Marshal(e.key.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: encode key: %w", e.key.Line, err)
+		}
+
+		c.out.Write(key)
+		c.out.WriteByte(':')
+
+		err = c.value(e.value, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.out.WriteByte('}')
+
+	return nil
+}
+
+// mappingEntries returns the entries of a mapping with merge keys resolved.
+// Explicit keys take precedence over merged keys.
+func (c *yamlConverter) mappingEntries(
+	n *yaml.Node, depth int,
+) ([]yamlEntry, error) {
+	var (
+		entries []yamlEntry
+		merged  []yamlEntry
+		seen    = make(map[string]bool)
+	)
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+
+		if key.Kind == yaml.ScalarNode && key.Tag == "!!merge" {
+			m, err := c.mergeEntries(value, depth)
+			if err != nil {
+				return nil, err
+			}
+
+			merged = append(merged, m...)
+
+			continue
+		}
+
+		if key.Kind != yaml.ScalarNode || key.ShortTag() != "!!str" {
+			return nil, fmt.Errorf(
+				"line %d: mapping keys must be strings", key.Line)
+		}
+
+		if seen[key.Value] {
+			return nil, fmt.Errorf(
+				"line %d: mapping key %q already defined",
+				key.Line, key.Value)
+		}
+
+		seen[key.Value] = true
+
+		entries = append(entries, yamlEntry{key: key, value: value})
+	}
+
+	for _, e := range merged {
+		if seen[e.key.Value] {
+			continue
+		}
+
+		seen[e.key.Value] = true
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+func (c *yamlConverter) mergeEntries(
+	n *yaml.Node, depth int,
+) ([]yamlEntry, error) {
+	err := c.visit(n, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Kind {
+	case yaml.AliasNode:
+		return c.mergeEntries(n.Alias, depth+1)
+	case yaml.MappingNode:
+		return c.mappingEntries(n, depth+1)
+	case yaml.SequenceNode:
+		var entries []yamlEntry
+
+		for _, item := range n.Content {
+			e, err := c.mergeEntries(item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, e...)
+		}
+
+		return entries, nil
+	}
+
+	return nil, fmt.Errorf("line %d: merge values must be mappings", n.Line)
+}
+
+func (c *yamlConverter) scalar(n *yaml.Node) error {
+	c.mark(n)
+
+	var v any
+
+	switch n.ShortTag() {
+	case "!!str", "!!binary", "!!timestamp":
+		// Keep timestamps as strings, they will be validated as such.
+		v = n.Value
+	default:
+		err := n.Decode(&v)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", n.Line, err)
+		}
+	}
+
+	if f, ok := v.(float64); ok && (math.IsInf(f, 0) || math.IsNaN(f)) {
+		return fmt.Errorf("line %d: %q can't be represented in JSON",
+			n.Line, n.Value)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("line %d: encode value: %w", n.Line, err)
+	}
+
+	c.out.Write(data)
+
+	return nil
+}
+
+// JSONToYAML converts JSON data to YAML, preserving the order of object keys.
+func JSONToYAML(data []byte) ([]byte, error) {
+	doc, err := ParseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncodeYAML(doc)
+}
+
+// ParseJSON parses JSON data as a YAML document node, preserving the order of
+// object keys.
+func ParseJSON(data []byte) (*yaml.Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	dec.UseNumber()
+
+	node, err := jsonNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dec.Token()
+	if !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	return &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{node},
+	}, nil
+}
+
+// EncodeYAML encodes a YAML node using two space indentation.
+func EncodeYAML(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+
+	enc.SetIndent(2)
+
+	err := enc.Encode(n)
+	if err != nil {
+		return nil, fmt.Errorf("encode YAML: %w", err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("encode YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// EncodeJSON encodes a YAML node as indented JSON.
+func EncodeJSON(n *yaml.Node) ([]byte, error) {
+	compact, _, err := NodeToJSON(n)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = json.Indent(&buf, compact, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("format JSON: %w", err)
+	}
+
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+func jsonNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			n := yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSON: %w", err)
+				}
+
+				key, _ := keyTok.(string)
+
+				value, err := jsonNode(dec)
+				if err != nil {
+					return nil, err
+				}
+
+				n.Content = append(n.Content, &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!str",
+					Value: key,
+				}, value)
+			}
+
+			_, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON: %w", err)
+			}
+
+			return &n, nil
+		case '[':
+			n := yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+			for dec.More() {
+				item, err := jsonNode(dec)
+				if err != nil {
+					return nil, err
+				}
+
+				n.Content = append(n.Content, item)
+			}
+
+			_, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON: %w", err)
+			}
+
+			return &n, nil
+		}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!float"
+
+		if _, err := strconv.ParseInt(t.String(), 10, 64); err == nil {
+			tag = "!!int"
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{
+			Kind: yaml.ScalarNode, Tag: "!!bool",
+			Value: strconv.FormatBool(t),
+		}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
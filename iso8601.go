@@ -0,0 +1,231 @@
+package revisor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/invopop/jsonschema"
+)
+
+// Nominal lengths used when comparing durations with calendar components.
+const (
+	nominalDay   = 24 * time.Hour
+	nominalWeek  = 7 * nominalDay
+	nominalMonth = 30 * nominalDay
+	nominalYear  = 365 * nominalDay
+)
+
+// ISODuration is an ISO 8601 duration, f.ex. "PT1H30M", that can be used with
+// JSON marshalling and unmarshalling.
+type ISODuration struct {
+	text     string
+	duration time.Duration
+}
+
+// ParseISODuration parses an ISO 8601 duration.
+func ParseISODuration(text string) (*ISODuration, error) {
+	d, err := parseISODuration(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ISODuration{
+		text:     text,
+		duration: d,
+	}, nil
+}
+
+func (d ISODuration) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: `An ISO 8601 duration, f.ex. "PT1H30M"`,
+	}
+}
+
+func (d *ISODuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.text) //nolint:wrapcheck
+}
+
+func (d *ISODuration) UnmarshalJSON(data []byte) error {
+	var text string
+
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	dur, err := parseISODuration(text)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", text, err)
+	}
+
+	d.text = text
+	d.duration = dur
+
+	return nil
+}
+
+// String returns the duration as it was written.
+func (d *ISODuration) String() string {
+	return d.text
+}
+
+// Nominal returns the nominal length of the duration, where a day is 24
+// hours, a month 30 days, and a year 365 days.
+func (d *ISODuration) Nominal() time.Duration {
+	return d.duration
+}
+
+var (
+	durationDateUnits = []isoDurationUnit{
+		{'Y', nominalYear}, {'M', nominalMonth},
+		{'W', nominalWeek}, {'D', nominalDay},
+	}
+	durationTimeUnits = []isoDurationUnit{
+		{'H', time.Hour}, {'M', time.Minute}, {'S', time.Second},
+	}
+)
+
+type isoDurationUnit struct {
+	Designator byte
+	Length     time.Duration
+}
+
+// parseISODuration parses a ISO 8601 duration and returns its nominal length.
+// Only the last component may have a decimal fraction.
+func parseISODuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(value, "P")
+	if !ok {
+		return 0, errors.New(`must start with "P"`)
+	}
+
+	datePart, timePart, hasTime := strings.Cut(rest, "T")
+
+	if datePart == "" && !hasTime {
+		return 0, errors.New("no duration components")
+	}
+
+	if hasTime && timePart == "" {
+		return 0, errors.New(`no time components after "T"`)
+	}
+
+	var (
+		total    float64
+		fraction bool
+	)
+
+	for _, part := range []struct {
+		Text  string
+		Units []isoDurationUnit
+	}{
+		{datePart, durationDateUnits},
+		{timePart, durationTimeUnits},
+	} {
+		text := part.Text
+		units := part.Units
+
+		for text != "" {
+			if fraction {
+				return 0, errors.New("only the last component can have a fraction")
+			}
+
+			end := strings.IndexFunc(text, func(r rune) bool {
+				return (r < '0' || r > '9') && r != '.' && r != ','
+			})
+			if end == -1 {
+				return 0, fmt.Errorf("missing designator after %q", text)
+			}
+
+			if end == 0 {
+				return 0, fmt.Errorf("expected a number before %q", text[0:1])
+			}
+
+			number := strings.Replace(text[:end], ",", ".", 1)
+
+			n, err := strconv.ParseFloat(number, 64)
+			if err != nil || strings.HasPrefix(number, ".") || strings.HasSuffix(number, ".") {
+				return 0, fmt.Errorf("invalid number %q", text[:end])
+			}
+
+			fraction = strings.Contains(number, ".")
+
+			idx := -1
+
+			for i, u := range units {
+				if u.Designator == text[end] {
+					idx = i
+
+					break
+				}
+			}
+
+			if idx == -1 {
+				return 0, fmt.Errorf("unexpected designator %q", text[end:end+1])
+			}
+
+			total += n * float64(units[idx].Length)
+			units = units[idx+1:]
+			text = text[end+1:]
+		}
+	}
+
+	if total > math.MaxInt64 {
+		return 0, errors.New("duration is too long")
+	}
+
+	return time.Duration(total), nil
+}
+
+// validateDuration validates an ISO 8601 duration against the duration limits
+// of the constraint.
+func validateDuration(value string, sc *StringConstraint) error {
+	d, err := parseISODuration(value)
+	if err != nil {
+		return err
+	}
+
+	if sc.MinDuration != nil && d < sc.MinDuration.Nominal() {
+		return fmt.Errorf("must be at least %s", sc.MinDuration)
+	}
+
+	if sc.MaxDuration != nil && d > sc.MaxDuration.Nominal() {
+		return fmt.Errorf("must be at most %s", sc.MaxDuration)
+	}
+
+	return nil
+}
+
+// validateDate validates an ISO 8601 calendar date, f.ex. "2024-02-29".
+func validateDate(value string) error {
+	if !dateExp.MatchString(value) {
+		return errors.New("expected a date in the format YYYY-MM-DD")
+	}
+
+	_, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return errors.New("not a valid calendar date")
+	}
+
+	return nil
+}
+
+var (
+	dateExp      = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	timeOfDayExp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9]([.,][0-9]+)?)?$`)
+)
+
+// validateTimeOfDay validates an ISO 8601 local time of day, f.ex. "14:30" or
+// "14:30:00".
+func validateTimeOfDay(value string) error {
+	if !timeOfDayExp.MatchString(value) {
+		return errors.New("expected a time in the format hh:mm or hh:mm:ss")
+	}
+
+	return nil
+}
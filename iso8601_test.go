@@ -0,0 +1,17 @@
+package revisor_test
+
+import "testing"
+
+func TestDurationConstraintDecoding(t *testing.T) {
+	assertDecodeError(t, `{
+  "name": "x",
+  "documents": [
+    {
+      "declares": "test/doc",
+      "attributes": {
+        "title": {"format": "duration", "maxDuration": "1H"}
+      }
+    }
+  ]
+}`, `invalid duration "1H"`)
+}
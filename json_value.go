@@ -0,0 +1,383 @@
+package revisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// JSONValueSchema is the subset of JSON schema that can be used to validate
+// JSON values: types, object properties, required properties, array items,
+// and enums.
+type JSONValueSchema struct {
+	Description string                      `json:"description,omitempty"`
+	Type        JSONValueTypes              `json:"type,omitempty"`
+	Enum        []any                       `json:"enum,omitempty"`
+	Properties  map[string]*JSONValueSchema `json:"properties,omitempty"`
+	Required    []string                    `json:"required,omitempty"`
+	Items       *JSONValueSchema            `json:"items,omitempty"`
+}
+
+func (s *JSONValueSchema) UnmarshalJSON(data []byte) error {
+	// Decode through a type without the UnmarshalJSON method, and keep
+	// the items as raw JSON so that we can tell null from a missing
+	// schema.
+	type schema JSONValueSchema
+
+	var v struct {
+		schema
+
+		Items json.RawMessage `json:"items,omitempty"`
+	}
+
+	// Decode strictly, the DisallowUnknownFields setting of the outer
+	// decoder doesn't carry over to custom unmarshalers.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&v)
+	if err != nil {
+		return fmt.Errorf("unmarshal JSON value schema: %w", err)
+	}
+
+	for name, p := range v.Properties {
+		if p == nil {
+			return fmt.Errorf(
+				"the schema of the property %q must not be null", name)
+		}
+	}
+
+	if v.Items != nil {
+		if string(v.Items) == "null" {
+			return errors.New("the items schema must not be null")
+		}
+
+		var items JSONValueSchema
+
+		err := json.Unmarshal(v.Items, &items)
+		if err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+
+		v.schema.Items = &items
+	}
+
+	*s = JSONValueSchema(v.schema)
+
+	return nil
+}
+
+// JSONValueTypes is a list of allowed JSON types, it's represented as a
+// string in JSON if there only is one type.
+type JSONValueTypes []string
+
+func (t JSONValueTypes) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{
+				Type:  "array",
+				Items: &jsonschema.Schema{Type: "string"},
+			},
+		},
+	}
+}
+
+func (t JSONValueTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0]) //nolint:wrapcheck
+	}
+
+	return json.Marshal([]string(t)) //nolint:wrapcheck
+}
+
+func (t *JSONValueTypes) UnmarshalJSON(data []byte) error {
+	var types []string
+
+	var single string
+
+	err := json.Unmarshal(data, &single)
+	if err == nil {
+		types = []string{single}
+	} else {
+		err := json.Unmarshal(data, &types)
+		if err != nil {
+			return errors.New("type must be a string or a list of strings")
+		}
+	}
+
+	for _, name := range types {
+		if !slices.Contains(jsonValueTypes, name) {
+			return fmt.Errorf("unknown JSON type %q, must be one of %s",
+				name, quotedSlice(jsonValueTypes))
+		}
+	}
+
+	*t = types
+
+	return nil
+}
+
+var jsonValueTypes = []string{
+	"object", "array", "string", "number", "integer", "boolean", "null",
+}
+
+// validateJSONValue validates that the value is a single JSON value, and
+// that it follows the schema if one is given.
+func validateJSONValue(value string, schema *JSONValueSchema) error {
+	dec := json.NewDecoder(strings.NewReader(value))
+
+	dec.UseNumber()
+
+	var v any
+
+	err := dec.Decode(&v)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	_, err = dec.Token()
+	if !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after the JSON value")
+	}
+
+	if schema == nil {
+		return nil
+	}
+
+	return schema.validate("", v)
+}
+
+func (s *JSONValueSchema) validate(path string, v any) error {
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool {
+		return jsonTypeMatches(t, v)
+	}) {
+		if len(s.Type) == 1 {
+			return jsonPathError(path, "expected %s, got %s",
+				jsonTypeName(s.Type[0]), jsonValueTypeName(v))
+		}
+
+		return jsonPathError(path, "expected one of the types %s, got %s",
+			quotedSlice(s.Type), jsonValueTypeName(v))
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool {
+		return reflect.DeepEqual(normaliseJSONValue(e), normaliseJSONValue(v))
+	}) {
+		return jsonPathError(path, "must be one of %s", jsonEnumString(s.Enum))
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			_, ok := val[name]
+			if !ok {
+				return jsonPathError(path, "missing required property %q", name)
+			}
+		}
+
+		names := make([]string, 0, len(s.Properties))
+
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+
+		slices.Sort(names)
+
+		for _, name := range names {
+			pv, ok := val[name]
+			if !ok {
+				continue
+			}
+
+			err := s.Properties[name].validate(
+				path+"/"+jsonPointerEscape(name), pv)
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		if s.Items == nil {
+			return nil
+		}
+
+		for i, item := range val {
+			err := s.Items.validate(path+"/"+strconv.Itoa(i), item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func jsonPathError(path string, format string, a ...any) error {
+	msg := fmt.Sprintf(format, a...)
+
+	if path == "" {
+		return errors.New(msg)
+	}
+
+	return fmt.Errorf("at %q: %s", path, msg)
+}
+
+func jsonPointerEscape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+func jsonTypeMatches(name string, v any) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		return name == "object"
+	case []any:
+		return name == "array"
+	case string:
+		return name == "string"
+	case bool:
+		return name == "boolean"
+	case nil:
+		return name == "null"
+	case json.Number:
+		if name == "number" {
+			return true
+		}
+
+		if name != "integer" {
+			return false
+		}
+
+		f, err := val.Float64()
+
+		return err == nil && f == math.Trunc(f)
+	}
+
+	return false
+}
+
+func jsonTypeName(name string) string {
+	switch name {
+	case "object", "array", "integer":
+		return "an " + name
+	}
+
+	return "a " + name
+}
+
+func jsonValueTypeName(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	case json.Number:
+		return "a number"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
+
+// normaliseJSONValue converts numbers to float64 so that values decoded with
+// and without UseNumber() can be compared.
+func normaliseJSONValue(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		f, err := val.Float64()
+		if err != nil {
+			return val.String()
+		}
+
+		return f
+	case map[string]any:
+		m := make(map[string]any, len(val))
+
+		for k, item := range val {
+			m[k] = normaliseJSONValue(item)
+		}
+
+		return m
+	case []any:
+		s := make([]any, len(val))
+
+		for i, item := range val {
+			s[i] = normaliseJSONValue(item)
+		}
+
+		return s
+	}
+
+	return v
+}
+
+func jsonEnumString(values []any) string {
+	s := make([]string, len(values))
+
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			s[i] = fmt.Sprintf("%v", v)
+
+			continue
+		}
+
+		s[i] = string(data)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// jsonSchema returns the JSON schema equivalent of the value schema.
+func (s *JSONValueSchema) jsonSchema() *jsonschema.Schema {
+	schema := jsonschema.Schema{
+		Description: s.Description,
+		Enum:        s.Enum,
+		Required:    s.Required,
+	}
+
+	switch len(s.Type) {
+	case 0:
+	case 1:
+		schema.Type = s.Type[0]
+	default:
+		for _, t := range s.Type {
+			schema.AnyOf = append(schema.AnyOf, &jsonschema.Schema{Type: t})
+		}
+	}
+
+	if len(s.Properties) > 0 {
+		names := make([]string, 0, len(s.Properties))
+
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+
+		slices.Sort(names)
+
+		schema.Properties = jsonschema.NewProperties()
+
+		for _, name := range names {
+			schema.Properties.Set(name, s.Properties[name].jsonSchema())
+		}
+	}
+
+	if s.Items != nil {
+		schema.Items = s.Items.jsonSchema()
+	}
+
+	return &schema
+}
@@ -0,0 +1,43 @@
+package revisor_test
+
+import "testing"
+
+func TestJSONValueSchemaDecoding(t *testing.T) {
+	cases := map[string]struct {
+		Schema string
+		Error  string
+	}{
+		"null property schema": {
+			Schema: `{"properties": {"a": null}}`,
+			Error:  `the schema of the property "a" must not be null`,
+		},
+		"null items schema": {
+			Schema: `{"items": null}`,
+			Error:  "the items schema must not be null",
+		},
+		"nested null items schema": {
+			Schema: `{"items": {"properties": {"a": {"items": null}}}}`,
+			Error:  "the items schema must not be null",
+		},
+		"nested unknown field": {
+			Schema: `{"items": {"type": "string", "nope": true}}`,
+			Error:  `unknown field "nope"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assertDecodeError(t, `{
+  "name": "x",
+  "documents": [
+    {
+      "declares": "test/doc",
+      "attributes": {
+        "title": {"format": "json", "jsonSchema": `+tc.Schema+`}
+      }
+    }
+  ]
+}`, tc.Error)
+		})
+	}
+}
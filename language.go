@@ -0,0 +1,44 @@
+package revisor
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// validateLanguageTag validates a BCP 47 language tag against the language
+// options of the constraint.
+func validateLanguageTag(value string, sc *StringConstraint) error {
+	// The language package accepts "_" as a separator, but BCP 47
+	// doesn't.
+	if strings.Contains(value, "_") {
+		return errors.New(`subtags must be separated by "-"`)
+	}
+
+	tag, err := language.Parse(value)
+	if err != nil {
+		return errors.New(strings.TrimPrefix(err.Error(), "language: "))
+	}
+
+	base, _, region := tag.Raw()
+
+	if len(sc.Languages) > 0 && !slices.ContainsFunc(sc.Languages, func(l string) bool {
+		return strings.EqualFold(l, base.String())
+	}) {
+		return fmt.Errorf("the language must be one of: %s",
+			strings.Join(sc.Languages, ", "))
+	}
+
+	if sc.RequireRegion && region.String() == "ZZ" {
+		return errors.New("a region is required, f.ex. \"sv-SE\"")
+	}
+
+	if sc.Canonical && tag.String() != value {
+		return fmt.Errorf("not in canonical form, expected %q", tag.String())
+	}
+
+	return nil
+}
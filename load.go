@@ -0,0 +1,462 @@
+package revisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// DecodeError is returned when a constraint set can't be decoded. Line and
+// Column are set when the position of the error is known.
+type DecodeError struct {
+	Name   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	var b strings.Builder
+
+	if e.Name != "" {
+		b.WriteString(e.Name)
+	}
+
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ":%d:%d", e.Line, e.Column)
+	}
+
+	if b.Len() > 0 {
+		b.WriteString(": ")
+	}
+
+	b.WriteString(e.Err.Error())
+
+	return b.String()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeConstraintSet strictly decodes a constraint set, unknown fields are
+// treated as errors. The name is used to identify the source in errors.
+func DecodeConstraintSet(name string, r io.Reader) (ConstraintSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ConstraintSet{}, &DecodeError{
+			Name: name,
+			Err:  fmt.Errorf("read constraint set: %w", err),
+		}
+	}
+
+	return decodeConstraintSet(name, data)
+}
+
+// LoadConstraintSets loads the named constraint set files from a filesystem.
+// Files with a ".yaml" or ".yml" extension are decoded as YAML, other files
+// as JSON.
+func LoadConstraintSets(fsys fs.FS, names ...string) ([]ConstraintSet, error) {
+	sets := make([]ConstraintSet, len(names))
+
+	for i, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("load constraints from %q: %w", name, err)
+		}
+
+		cs, err := decodeConstraintSetFile(name, data)
+		if err != nil {
+			return nil, err
+		}
+
+		sets[i] = cs
+	}
+
+	return sets, nil
+}
+
+// GlobConstraintSets loads the constraint set files that match the glob
+// patterns (as used by fs.Glob). The matches for each pattern are loaded in
+// lexical order, and a file matched by several patterns is only loaded once.
+// It's an error for a pattern to not match any files.
+func GlobConstraintSets(fsys fs.FS, patterns ...string) ([]ConstraintSet, error) {
+	var names []string
+
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no constraint sets matched %q", pattern)
+		}
+
+		slices.Sort(matches)
+
+		for _, m := range matches {
+			if slices.Contains(names, m) {
+				continue
+			}
+
+			names = append(names, m)
+		}
+	}
+
+	return LoadConstraintSets(fsys, names...)
+}
+
+// LoadConstraintSetsDir loads all the constraint set files (*.json, *.yaml
+// and *.yml) in a directory in lexical order. File extensions are matched
+// case insensitively.
+func LoadConstraintSetsDir(dir string) ([]ConstraintSet, error) {
+	fsys := os.DirFS(dir)
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("list constraint sets: %w", err)
+	}
+
+	var names []string
+
+	for _, e := range entries {
+		name := e.Name()
+
+		if e.IsDir() || !isConstraintSetFile(name) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no constraint sets found in %q", dir)
+	}
+
+	return LoadConstraintSets(fsys, names...)
+}
+
+// LoadConstraintSetFiles loads constraint sets from files.
+func LoadConstraintSetFiles(paths ...string) ([]ConstraintSet, error) {
+	sets := make([]ConstraintSet, len(paths))
+
+	for i, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("load constraints from %q: %w", p, err)
+		}
+
+		cs, err := decodeConstraintSetFile(p, data)
+		if err != nil {
+			return nil, err
+		}
+
+		sets[i] = cs
+	}
+
+	return sets, nil
+}
+
+// decodeConstraintSetFile decodes a constraint set as YAML or JSON depending
+// on the file extension.
+func decodeConstraintSetFile(name string, data []byte) (ConstraintSet, error) {
+	if isYAMLFile(name) {
+		return decodeConstraintSetYAML(name, data)
+	}
+
+	return decodeConstraintSet(name, data)
+}
+
+// isConstraintSetFile checks if the file has the extension of a supported
+// constraint set format.
+func isConstraintSetFile(name string) bool {
+	return strings.EqualFold(path.Ext(name), ".json") || isYAMLFile(name)
+}
+
+func isYAMLFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func decodeConstraintSet(name string, data []byte) (ConstraintSet, error) {
+	return decodeConstraintSetJSON(name, data, func(offset int64) (int, int) {
+		return position(data, offset)
+	})
+}
+
+// decodeConstraintSetJSON decodes a constraint set from JSON data, pos is used
+// to translate offsets in the data to positions in the source.
+func decodeConstraintSetJSON(
+	name string, data []byte, pos func(offset int64) (int, int),
+) (ConstraintSet, error) {
+	var cs ConstraintSet
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&cs)
+	if err != nil {
+		de := newDecodeError(name, data, err, &cs, pos)
+
+		outdated := outdatedFormatError(data)
+		if outdated != nil {
+			de.Err = fmt.Errorf("%w: %w", outdated, de.Err)
+		}
+
+		return ConstraintSet{}, de
+	}
+
+	_, err = dec.Token()
+	if !errors.Is(err, io.EOF) {
+		line, col := pos(dec.InputOffset())
+
+		return ConstraintSet{}, &DecodeError{
+			Name:   name,
+			Line:   line,
+			Column: col,
+			Err:    errors.New("unexpected data after the constraint set"),
+		}
+	}
+
+	return cs, nil
+}
+
+// newDecodeError creates a DecodeError with the position of the error, if it
+// can be determined.
+func newDecodeError(
+	name string, data []byte, err error, target any,
+	pos func(offset int64) (int, int),
+) *DecodeError {
+	de := DecodeError{
+		Name: name,
+		Err:  err,
+	}
+
+	offset := int64(-1)
+
+	// Only trust offsets from unwrapped errors, errors from custom
+	// unmarshalers have offsets relative to the value they decoded.
+	switch e := err.(type) { //nolint:errorlint
+	case *json.SyntaxError:
+		// The offset is after the invalid character.
+		offset = max(e.Offset-1, 0)
+	case *json.UnmarshalTypeError:
+		// The offset is after the value, point to its start instead.
+		offset = tokenStart(data, e.Offset)
+	default:
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			offset = int64(len(data))
+
+			break
+		}
+
+		field, ok := unknownField(err)
+		if ok {
+			offset = locateUnknownField(
+				data, reflect.TypeOf(target).Elem(), field)
+		}
+	}
+
+	if offset >= 0 {
+		de.Line, de.Column = pos(offset)
+	}
+
+	return &de
+}
+
+// position converts a byte offset to a 1-based line and column.
+func position(data []byte, offset int64) (int, int) {
+	offset = min(offset, int64(len(data)))
+
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+
+	return line, col
+}
+
+// unknownField extracts the field name from the error that encoding/json
+// returns when DisallowUnknownFields is used.
+func unknownField(err error) (string, bool) {
+	const prefix = `json: unknown field "`
+
+	msg := err.Error()
+
+	idx := strings.LastIndex(msg, prefix)
+	if idx == -1 || !strings.HasSuffix(msg, `"`) {
+		return "", false
+	}
+
+	return msg[idx+len(prefix) : len(msg)-1], true
+}
+
+var (
+	constraintMapType = reflect.TypeFor[ConstraintMap]()
+	unmarshalerType   = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// locateUnknownField walks the JSON data alongside the target type and returns
+// the offset of the first object key that doesn't match a field, or -1 if it
+// couldn't be found.
+func locateUnknownField(data []byte, t reflect.Type, field string) int64 {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	offset, _ := walkUnknownFields(dec, data, t, field)
+
+	return offset
+}
+
+func walkUnknownFields(
+	dec *json.Decoder, data []byte, t reflect.Type, field string,
+) (int64, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == constraintMapType {
+		t = reflect.TypeFor[map[string]StringConstraint]()
+	} else if t != nil && reflect.PointerTo(t).Implements(unmarshalerType) {
+		// We don't know what custom unmarshalers accept.
+		t = nil
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return -1, err //nolint:wrapcheck
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return -1, nil
+	}
+
+	switch delim {
+	case '[':
+		var elem reflect.Type
+
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+
+		for dec.More() {
+			offset, err := walkUnknownFields(dec, data, elem, field)
+			if err != nil || offset >= 0 {
+				return offset, err
+			}
+		}
+	case '{':
+		for dec.More() {
+			start := skipSeparators(data, dec.InputOffset())
+
+			keyTok, err := dec.Token()
+			if err != nil {
+				return -1, err //nolint:wrapcheck
+			}
+
+			key, _ := keyTok.(string)
+
+			var (
+				value reflect.Type
+				known = true
+			)
+
+			switch {
+			case t == nil:
+			case t.Kind() == reflect.Map:
+				value = t.Elem()
+			case t.Kind() == reflect.Struct:
+				value, known = structField(t, key)
+			}
+
+			if !known && key == field {
+				return start, nil
+			}
+
+			offset, err := walkUnknownFields(dec, data, value, field)
+			if err != nil || offset >= 0 {
+				return offset, err
+			}
+		}
+	}
+
+	// Consume the closing delimiter.
+	_, err = dec.Token()
+	if err != nil {
+		return -1, err //nolint:wrapcheck
+	}
+
+	return -1, nil
+}
+
+// tokenStart returns the start offset of the token that ends at the offset.
+func tokenStart(data []byte, offset int64) int64 {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var prevEnd int64
+
+	for {
+		_, err := dec.Token()
+		if err != nil {
+			return offset
+		}
+
+		end := dec.InputOffset()
+		if end >= offset {
+			return skipSeparators(data, prevEnd)
+		}
+
+		prevEnd = end
+	}
+}
+
+// skipSeparators skips whitespace and separators to find the start of the
+// next token.
+func skipSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
+
+// structField finds the type of the struct field that a JSON key would be
+// decoded into, using the same case-insensitive matching as encoding/json.
+func structField(t reflect.Type, key string) (reflect.Type, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+
+		if strings.EqualFold(name, key) {
+			return f.Type, true
+		}
+	}
+
+	return nil, false
+}
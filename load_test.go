@@ -0,0 +1,193 @@
+package revisor_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ttab/revisor"
+)
+
+func TestLoadConstraintSets(t *testing.T) {
+	fsys := fstest.MapFS{
+		"specs/b.json": {Data: []byte(`{"name": "b"}`)},
+		"specs/a.json": {Data: []byte(`{"name": "a"}`)},
+		"other/c.json": {Data: []byte(`{"name": "c"}`)},
+	}
+
+	sets, err := revisor.LoadConstraintSets(fsys, "specs/b.json", "other/c.json")
+	mustf(t, err, "load constraint sets")
+
+	assertSetNames(t, sets, "b", "c")
+
+	sets, err = revisor.GlobConstraintSets(fsys, "specs/*.json", "*/*.json")
+	mustf(t, err, "glob constraint sets")
+
+	assertSetNames(t, sets, "a", "b", "c")
+
+	_, err = revisor.GlobConstraintSets(fsys, "nope/*.json")
+	if err == nil {
+		t.Fatal("expected an error when a pattern doesn't match any files")
+	}
+
+	sets, err = revisor.LoadConstraintSetsDir("internal/revisorschemas")
+	mustf(t, err, "load constraint sets from directory")
+
+	assertSetNames(t, sets, "core-planning", "core", "tt-planning", "tt")
+
+	dir := t.TempDir()
+
+	for name, data := range map[string]string{
+		"A.JSON":   `{"name": "a"}`,
+		"b.Yml":    "name: b\n",
+		"c.txt":    "not a constraint set",
+		"d.Json":   `{"name": "d"}`,
+		"e.jsonld": "{}",
+	} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600)
+		mustf(t, err, "write %s", name)
+	}
+
+	sets, err = revisor.LoadConstraintSetsDir(dir)
+	mustf(t, err, "load constraint sets with upper case extensions")
+
+	assertSetNames(t, sets, "a", "b", "d")
+}
+
+func TestLoadConstraintSetsZip(t *testing.T) {
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	w, err := zw.Create("constraints/zipped.json")
+	mustf(t, err, "create zip entry")
+
+	_, err = w.Write([]byte(`{"name": "zipped"}`))
+	mustf(t, err, "write zip entry")
+
+	mustf(t, zw.Close(), "close zip writer")
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	mustf(t, err, "open zip file")
+
+	sets, err := revisor.GlobConstraintSets(zr, "constraints/*.json")
+	mustf(t, err, "load constraint sets from zip file")
+
+	assertSetNames(t, sets, "zipped")
+}
+
+func TestDecodeConstraintSetErrors(t *testing.T) {
+	cases := map[string]struct {
+		Source string
+		Line   int
+		Column int
+		Error  string
+	}{
+		"syntax": {
+			Source: "{\n  \"name\": \"x\",\n  \"documents\": [}\n}",
+			Line:   3,
+			Column: 17,
+			Error:  "invalid character",
+		},
+		"type": {
+			Source: "{\n  \"name\": 12\n}",
+			Line:   2,
+			Column: 11,
+			Error:  "cannot unmarshal number",
+		},
+		"unknown field": {
+			Source: "{\n  \"name\": \"x\",\n  \"nmae\": \"y\"\n}",
+			Line:   3,
+			Column: 3,
+			Error:  `unknown field "nmae"`,
+		},
+		"nested unknown field": {
+			Source: `{
+  "name": "x",
+  "documents": [
+    {
+      "declares": "test/doc",
+      "meta": [
+        {
+          "declares": {"type": "test/meta"},
+          "data": {
+            "name": {"optional": true, "declares": "nope"}
+          }
+        }
+      ]
+    }
+  ]
+}`,
+			Line:   10,
+			Column: 40,
+			Error:  `unknown field "declares"`,
+		},
+		"trailing data": {
+			Source: "{\"name\": \"x\"}\n{}",
+			Line:   2,
+			Column: 2,
+			Error:  "unexpected data",
+		},
+		"truncated": {
+			Source: "{\n  \"name\": \"x\"",
+			Line:   2,
+			Column: 14,
+			Error:  "unexpected EOF",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := revisor.DecodeConstraintSet("spec.json",
+				strings.NewReader(tc.Source))
+			if err == nil {
+				t.Fatal("expected decoding to fail")
+			}
+
+			var de *revisor.DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("expected a DecodeError, got %T: %v", err, err)
+			}
+
+			if de.Line != tc.Line || de.Column != tc.Column {
+				t.Errorf("expected the error to be at %d:%d, got %d:%d",
+					tc.Line, tc.Column, de.Line, de.Column)
+			}
+
+			if !strings.HasPrefix(err.Error(), "spec.json:") ||
+				!strings.Contains(err.Error(), tc.Error) {
+				t.Errorf("unexpected error message: %v", err)
+			}
+		})
+	}
+}
+
+// assertDecodeError checks that decoding the constraint set fails with an
+// error that contains want.
+func assertDecodeError(t *testing.T, source string, want string) {
+	t.Helper()
+
+	_, err := revisor.DecodeConstraintSet("spec.json", strings.NewReader(source))
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected an error containing %q, got: %v", want, err)
+	}
+}
+
+func assertSetNames(t *testing.T, sets []revisor.ConstraintSet, names ...string) {
+	t.Helper()
+
+	got := make([]string, len(sets))
+
+	for i := range sets {
+		got[i] = sets[i].Name
+	}
+
+	if strings.Join(got, ",") != strings.Join(names, ",") {
+		t.Fatalf("expected the constraint sets %v, got %v", names, got)
+	}
+}
@@ -0,0 +1,248 @@
+package revisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/ttab/revisor/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentFormatVersion is the version of the constraint set format that is
+// implemented by this version of revisor. Constraint sets that don't specify
+// a version, or that specify version 0, are assumed to use the current
+// version.
+const CurrentFormatVersion = 1
+
+// FormatMigration upgrades constraint sets from one version of the format to
+// the next.
+type FormatMigration struct {
+	// From is the version that the migration upgrades from, the migrated
+	// constraint set will have the version From+1.
+	From        int
+	Description string
+	// Migrate modifies the constraint set in place. The constraint set is
+	// represented as a YAML mapping node, also for JSON constraint sets,
+	// so that the order of keys and any YAML comments are preserved. The
+	// version is updated after the migration has been applied.
+	Migrate func(spec *yaml.Node) error
+}
+
+// formatMigrations is the registry of the built in format migrations. Every
+// breaking change to the constraint set format must bump
+// CurrentFormatVersion and add a migration from the previous version.
+var formatMigrations []FormatMigration
+
+// FormatMigrations returns the built in format migrations.
+func FormatMigrations() []FormatMigration {
+	return slices.Clone(formatMigrations)
+}
+
+// FormatMigrationResult describes the result of a format migration.
+type FormatMigrationResult struct {
+	FromVersion int
+	ToVersion   int
+	// Applied contains the descriptions of the migrations that were
+	// applied.
+	Applied []string
+}
+
+// Changed returns true if the constraint set was migrated.
+func (r FormatMigrationResult) Changed() bool {
+	return len(r.Applied) > 0
+}
+
+// MigrateConstraintSetFormat upgrades a constraint set file to the latest
+// format version using the built in format migrations. See
+// MigrateConstraintSetFormatWith.
+func MigrateConstraintSetFormat(
+	name string, data []byte,
+) ([]byte, FormatMigrationResult, error) {
+	return MigrateConstraintSetFormatWith(name, data, formatMigrations)
+}
+
+// MigrateConstraintSetFormatWith upgrades a constraint set file using the
+// given migrations. The name is used to decide if the data is YAML or JSON,
+// and the migrated constraint set is returned in the same format. The data is
+// returned as-is if no migrations were needed.
+//
+// A constraint set without a version, or with version 0, is treated as
+// being of the current version. The target
+// version is CurrentFormatVersion, or the version produced by the last
+// migration if that's higher.
+func MigrateConstraintSetFormatWith(
+	name string, data []byte, migrations []FormatMigration,
+) ([]byte, FormatMigrationResult, error) {
+	isYAML := isYAMLFile(name)
+
+	var (
+		doc *yaml.Node
+		err error
+	)
+
+	if isYAML {
+		doc, err = internal.ParseYAML(data)
+	} else {
+		doc, err = internal.ParseJSON(data)
+	}
+
+	if err != nil {
+		return nil, FormatMigrationResult{}, &DecodeError{Name: name, Err: err}
+	}
+
+	spec := doc.Content[0]
+	if spec.Kind != yaml.MappingNode {
+		return nil, FormatMigrationResult{}, &DecodeError{
+			Name: name,
+			Err:  errors.New("a constraint set must be an object"),
+		}
+	}
+
+	version, err := formatVersion(spec)
+	if err != nil {
+		return nil, FormatMigrationResult{}, &DecodeError{Name: name, Err: err}
+	}
+
+	byVersion := make(map[int]FormatMigration, len(migrations))
+	target := CurrentFormatVersion
+
+	for _, m := range migrations {
+		if _, exists := byVersion[m.From]; exists {
+			return nil, FormatMigrationResult{}, fmt.Errorf(
+				"more than one migration from version %d", m.From)
+		}
+
+		byVersion[m.From] = m
+		target = max(target, m.From+1)
+	}
+
+	result := FormatMigrationResult{
+		FromVersion: version,
+		ToVersion:   version,
+	}
+
+	if version > target {
+		return nil, result, fmt.Errorf(
+			"%s: format version %d is newer than the supported version %d",
+			name, version, target)
+	}
+
+	for v := version; v < target; v++ {
+		m, ok := byVersion[v]
+		if !ok {
+			return nil, result, fmt.Errorf(
+				"%s: no migration from format version %d", name, v)
+		}
+
+		err := m.Migrate(spec)
+		if err != nil {
+			return nil, result, fmt.Errorf(
+				"%s: migrate from format version %d: %w", name, v, err)
+		}
+
+		setFormatVersion(spec, v+1)
+
+		result.ToVersion = v + 1
+		result.Applied = append(result.Applied, m.Description)
+	}
+
+	if !result.Changed() {
+		return data, result, nil
+	}
+
+	var out []byte
+
+	if isYAML {
+		out, err = internal.EncodeYAML(doc)
+	} else {
+		out, err = internal.EncodeJSON(doc)
+	}
+
+	if err != nil {
+		return nil, result, fmt.Errorf("%s: encode migrated constraint set: %w",
+			name, err)
+	}
+
+	// Verify that we produced a valid constraint set if we have migrated
+	// to the current version.
+	if result.ToVersion == CurrentFormatVersion {
+		_, err := decodeConstraintSetFile(name, out)
+		if err != nil {
+			return nil, result, fmt.Errorf(
+				"the migrated constraint set is invalid: %w", err)
+		}
+	}
+
+	return out, result, nil
+}
+
+func formatVersion(spec *yaml.Node) (int, error) {
+	_, value := mappingValue(spec, "version")
+	if value == nil {
+		return CurrentFormatVersion, nil
+	}
+
+	version, err := strconv.Atoi(value.Value)
+	if value.Kind != yaml.ScalarNode || err != nil {
+		return 0, fmt.Errorf("line %d: the version must be an integer",
+			value.Line)
+	}
+
+	if version == 0 {
+		return CurrentFormatVersion, nil
+	}
+
+	return version, nil
+}
+
+func setFormatVersion(spec *yaml.Node, version int) {
+	_, value := mappingValue(spec, "version")
+	if value == nil {
+		value = &yaml.Node{}
+
+		spec.Content = append([]*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"},
+			value,
+		}, spec.Content...)
+	}
+
+	// Only update the value so that comments are kept.
+	value.Kind = yaml.ScalarNode
+	value.Style = 0
+	value.Tag = "!!int"
+	value.Value = strconv.Itoa(version)
+}
+
+// mappingValue returns the key and value nodes for a key in a mapping.
+func mappingValue(n *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i], n.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// outdatedFormatError checks if a constraint set that failed to decode uses
+// an older format version, and returns an error that suggests a migration if
+// it does.
+func outdatedFormatError(data []byte) error {
+	var versioned struct {
+		Version *int `json:"version"`
+	}
+
+	err := json.NewDecoder(bytes.NewReader(data)).Decode(&versioned)
+	if err != nil || versioned.Version == nil || *versioned.Version == 0 ||
+		*versioned.Version >= CurrentFormatVersion {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"format version %d is outdated, the current version is %d, use \"revisor migrate\" to upgrade",
+		*versioned.Version, CurrentFormatVersion)
+}
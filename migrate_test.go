@@ -0,0 +1,170 @@
+package revisor_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ttab/revisor"
+	"gopkg.in/yaml.v3"
+)
+
+// renameKeyMigration returns a migration that renames a top level key.
+func renameKeyMigration(from int, oldKey, newKey string) revisor.FormatMigration {
+	return revisor.FormatMigration{
+		From:        from,
+		Description: "rename " + oldKey + " to " + newKey,
+		Migrate: func(spec *yaml.Node) error {
+			for i := 0; i < len(spec.Content); i += 2 {
+				if spec.Content[i].Value == oldKey {
+					spec.Content[i].Value = newKey
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func TestMigrateConstraintSetFormat(t *testing.T) {
+	migrations := []revisor.FormatMigration{
+		renameKeyMigration(1, "title", "name"),
+	}
+
+	jsonSource := `{
+  "version": 1,
+  "title": "old",
+  "documents": [{"declares": "test/doc"}]
+}`
+
+	out, res, err := revisor.MigrateConstraintSetFormatWith(
+		"old.json", []byte(jsonSource), migrations)
+	mustf(t, err, "migrate JSON constraint set")
+
+	wantJSON := `{
+  "version": 2,
+  "name": "old",
+  "documents": [
+    {
+      "declares": "test/doc"
+    }
+  ]
+}
+`
+
+	if diff := cmp.Diff(wantJSON, string(out)); diff != "" {
+		t.Fatalf("migrated JSON mismatch (-want +got):\n%s", diff)
+	}
+
+	if res.FromVersion != 1 || res.ToVersion != 2 ||
+		len(res.Applied) != 1 || res.Applied[0] != "rename title to name" {
+		t.Fatalf("unexpected migration result: %#v", res)
+	}
+
+	yamlSource := `# An old constraint set.
+version: 1
+title: old # The name of the set.
+`
+
+	out, _, err = revisor.MigrateConstraintSetFormatWith(
+		"old.yaml", []byte(yamlSource), migrations)
+	mustf(t, err, "migrate YAML constraint set")
+
+	wantYAML := `# An old constraint set.
+version: 2
+name: old # The name of the set.
+`
+
+	if diff := cmp.Diff(wantYAML, string(out)); diff != "" {
+		t.Fatalf("migrated YAML mismatch (-want +got):\n%s", diff)
+	}
+
+	current := []byte(`{"version": 2, "name": "current"}`)
+
+	out, res, err = revisor.MigrateConstraintSetFormatWith(
+		"current.json", current, migrations)
+	mustf(t, err, "migrate current constraint set")
+
+	if res.Changed() || !bytes.Equal(out, current) {
+		t.Fatal("expected a current constraint set to be left as is")
+	}
+
+	current = []byte(`{"version": 1, "name": "current"}`)
+
+	out, res, err = revisor.MigrateConstraintSetFormat(
+		"current.json", current)
+	mustf(t, err, "migrate with built in migrations")
+
+	if res.Changed() || !bytes.Equal(out, current) {
+		t.Fatal("expected a current constraint set to be left as is")
+	}
+
+	for _, source := range []string{
+		`{"name": "unversioned"}`,
+		`{"version": 0, "name": "unversioned"}`,
+	} {
+		out, res, err = revisor.MigrateConstraintSetFormat(
+			"unversioned.json", []byte(source))
+		mustf(t, err, "migrate %s", source)
+
+		if res.Changed() || res.FromVersion != revisor.CurrentFormatVersion ||
+			string(out) != source {
+			t.Fatalf("expected %s to be treated as the current version, got: %#v",
+				source, res)
+		}
+	}
+}
+
+func TestMigrateConstraintSetFormatChain(t *testing.T) {
+	migrations := []revisor.FormatMigration{
+		renameKeyMigration(2, "b", "c"),
+		renameKeyMigration(1, "a", "b"),
+	}
+
+	out, res, err := revisor.MigrateConstraintSetFormatWith(
+		"spec.yaml", []byte("version: 1\nname: x\na: 1\n"), migrations)
+	mustf(t, err, "migrate constraint set")
+
+	if diff := cmp.Diff("version: 3\nname: x\nc: 1\n", string(out)); diff != "" {
+		t.Fatalf("migrated YAML mismatch (-want +got):\n%s", diff)
+	}
+
+	if res.FromVersion != 1 || res.ToVersion != 3 || len(res.Applied) != 2 {
+		t.Fatalf("unexpected migration result: %#v", res)
+	}
+
+	_, _, err = revisor.MigrateConstraintSetFormatWith(
+		"spec.yaml", []byte("version: 4\nname: x\n"), migrations)
+	if err == nil || !strings.Contains(err.Error(), "newer than the supported version 3") {
+		t.Fatalf("expected an error for a newer version, got: %v", err)
+	}
+
+	_, _, err = revisor.MigrateConstraintSetFormatWith(
+		"spec.yaml", []byte("version: 1\nname: x\n"), migrations[:1])
+	if err == nil || !strings.Contains(err.Error(), "no migration from format version 1") {
+		t.Fatalf("expected an error for a missing migration, got: %v", err)
+	}
+}
+
+func TestConstraintSetVersion(t *testing.T) {
+	_, err := revisor.NewValidator(revisor.ConstraintSet{
+		Name:    "future",
+		Version: revisor.CurrentFormatVersion + 1,
+	})
+	if err == nil || !strings.Contains(err.Error(), "revisor needs to be upgraded") {
+		t.Fatalf("expected an error for a newer version, got: %v", err)
+	}
+
+	_, err = revisor.NewValidator(revisor.ConstraintSet{
+		Name:    "current",
+		Version: revisor.CurrentFormatVersion,
+	}, revisor.ConstraintSet{
+		Name: "unversioned",
+	})
+	mustf(t, err, "create validator")
+
+	_, err = revisor.DecodeConstraintSet("zero.json", strings.NewReader(
+		`{"version": 0, "name": "zero"}`))
+	mustf(t, err, "decode constraint set with version 0")
+}
@@ -21,8 +21,14 @@ const (
 // validation are removed if their count constraints allow it; otherwise the
 // errors cascade up to the nearest removable ancestor, or are reported at the
 // document root.
+//
+// Prune accepts the same options as ValidateDocument. Blocks and values that
+// use a deprecation that the deprecation handler decides to enforce are
+// treated as invalid, and are removed or cleared where possible. A HTML value
+// that uses an enforced deprecation is treated as invalid as a whole.
 func (v *Validator) Prune(
 	ctx context.Context, document *newsdoc.Document,
+	opts ...ValidationOptionFunc,
 ) ([]ValidationResult, error) {
 	var res []ValidationResult
 
@@ -34,9 +40,20 @@ func (v *Validator) Prune(
 	var declared bool
 
 	vCtx := ValidationContext{
-		coll:         ValueDiscarder{},
-		ValidateHTML: v.validateHTML,
-		ValidateEnum: v.enums.ValidValue,
+		coll:             ValueDiscarder{},
+		variants:         v.variants,
+		formats:          v.formats,
+		htmlDeprecations: v.htmlDeprecations,
+		ValidateHTML:     v.validateHTML,
+		ValidateEnum:     v.enums.ValidValue,
+	}
+
+	for i := range opts {
+		opts[i](&vCtx)
+	}
+
+	if vCtx.cov != nil {
+		vCtx.ValidateEnum = v.coverageEnumValidator(vCtx.cov)
 	}
 
 	_, err := uuid.Parse(document.UUID)
@@ -60,6 +77,16 @@ func (v *Validator) Prune(
 			declared = true
 		}
 
+		// An enforced document deprecation can't be pruned, so it's
+		// reported as is.
+		res, err = checkDeprecation(
+			ctx, vCtx, res, document,
+			DeprecationContext{},
+			v.documents[i].Deprecated)
+		if err != nil {
+			return nil, err
+		}
+
 		blockConstraints = append(blockConstraints, v.documents[i])
 		attributeConstraints = append(
 			attributeConstraints, v.documents[i].Attributes)
@@ -72,8 +99,13 @@ func (v *Validator) Prune(
 		})
 	}
 
-	res = append(res, pruneDocumentAttributes(
-		attributeConstraints, document, &vCtx)...)
+	attrRes, err := pruneDocumentAttributes(
+		ctx, attributeConstraints, document, &vCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	res = append(res, attrRes...)
 
 	for _, kind := range blockKinds {
 		blocks := getDocumentBlocks(document, kind)
@@ -225,6 +257,22 @@ func (v *Validator) pruneBlockSlice(
 			continue
 		}
 
+		enforced, err := enforcedBlockDeprecations(
+			ctx, doc, &blocks[i], kind, i, vCtx,
+			matchInfos[i].matchedBlockPtrs)
+		if err != nil {
+			return pruneOK, blocks, nil, err
+		}
+
+		if len(enforced) > 0 {
+			removals = append(removals, removalCandidate{
+				index:      i,
+				cascadeErr: enforced,
+			})
+
+			continue
+		}
+
 		status, errs, err := v.pruneBlock(
 			ctx, doc, &blocks[i], vCtx,
 			matchInfos[i].matchedConstraints,
@@ -335,7 +383,7 @@ func (v *Validator) pruneBlockSlice(
 
 			if !minOK || !exactOK {
 				errResult := ValidationResult{
-					Error: constraint.DescribeCountConstraint(kind),
+					Error: constraint.DescribeCountConstraintWithFormats(kind, v.formats),
 				}
 
 				if documentLevel {
@@ -395,6 +443,98 @@ func matchBlock(
 	return info
 }
 
+// enforcedBlockDeprecations runs the deprecation handler for the
+// deprecations of the constraints that matched a block, and returns the
+// results for the deprecations that should be enforced.
+func enforcedBlockDeprecations(
+	ctx context.Context, doc *newsdoc.Document,
+	b *newsdoc.Block, kind BlockKind, index int,
+	vCtx ValidationContext, constraints []*BlockConstraint,
+) ([]ValidationResult, error) {
+	var res []ValidationResult
+
+	entity := EntityRef{
+		RefType:   RefTypeBlock,
+		Index:     index,
+		BlockKind: kind,
+		Type:      b.Type,
+		Rel:       b.Rel,
+	}
+
+	for _, c := range constraints {
+		r, err := checkDeprecation(ctx, vCtx, nil, doc,
+			DeprecationContext{
+				Entity: &entity,
+				Block:  b,
+			}, c.Deprecated)
+		if err != nil {
+			return nil, err
+		}
+
+		// The block entity is added when the block is removed, or
+		// when the error cascades.
+		for j := range r {
+			r[j].Entity = nil
+		}
+
+		res = append(res, r...)
+	}
+
+	return res, nil
+}
+
+// pruneValueProblem validates a value and returns the problem with it, if
+// any. Values that use a deprecation that should be enforced are treated as
+// invalid.
+func pruneValueProblem(
+	ctx context.Context, doc *newsdoc.Document,
+	vCtx *ValidationContext, dCtx DeprecationContext,
+	check StringConstraint, value string, exists bool,
+) (*ValidationResult, error) {
+	depr, err := check.Validate(value, exists, vCtx)
+	if err != nil {
+		return &ValidationResult{Error: err.Error()}, nil
+	}
+
+	if !exists || value == "" {
+		return nil, nil //nolint: nilnil
+	}
+
+	dCtx.Value = &value
+
+	enforced, err := checkValueDeprecation(
+		ctx, *vCtx, nil, doc, dCtx, check, value, depr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(enforced) == 0 {
+		return nil, nil //nolint: nilnil
+	}
+
+	problem := enforced[0]
+
+	problem.Entity = nil
+
+	return &problem, nil
+}
+
+// normalise returns the normalised form of a valid value, and false if the
+// value shouldn't be changed.
+func (sc StringConstraint) normalise(value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+
+	//nolint: exhaustive
+	switch sc.Format {
+	case StringFormatColour:
+		return normaliseColour(value, sc.ColourFormats, sc.NormaliseColour)
+	}
+
+	return "", false
+}
+
 // pruneExcessBlocks removes blocks that exceed a constraint's Count or
 // MaxCount limit, keeping the first N matching blocks per constraint.
 func pruneExcessBlocks(
@@ -507,8 +647,12 @@ func (v *Validator) pruneBlock(
 	var res []ValidationResult
 
 	// Prune attributes.
-	status, errs := pruneBlockAttributes(
-		matchedAttrConstraints, b, &vCtx, declaredAttributes)
+	status, errs, err := pruneBlockAttributes(
+		ctx, doc, matchedAttrConstraints, b, &vCtx, declaredAttributes)
+	if err != nil {
+		return pruneOK, nil, err
+	}
+
 	if status == pruneRemoveMe {
 		return pruneRemoveMe, errs, nil
 	}
@@ -516,7 +660,12 @@ func (v *Validator) pruneBlock(
 	res = append(res, errs...)
 
 	// Prune data.
-	status, errs = pruneBlockData(b, matchedDataConstraints, &vCtx)
+	status, errs, err = pruneBlockData(
+		ctx, doc, b, matchedDataConstraints, &vCtx)
+	if err != nil {
+		return pruneOK, nil, err
+	}
+
 	if status == pruneRemoveMe {
 		return pruneRemoveMe, errs, nil
 	}
@@ -552,10 +701,11 @@ func (v *Validator) pruneBlock(
 // attributes are cleared. If a required attribute is invalid and cannot be
 // fixed, pruneRemoveMe is returned.
 func pruneBlockAttributes(
+	ctx context.Context, doc *newsdoc.Document,
 	constraints []ConstraintMap, b *newsdoc.Block,
 	vCtx *ValidationContext,
 	declaredAttributes map[blockAttributeKey]bool,
-) (pruneStatus, []ValidationResult) {
+) (pruneStatus, []ValidationResult, error) {
 	var res []ValidationResult
 
 	for i := range constraints {
@@ -568,16 +718,29 @@ func pruneBlockAttributes(
 			// Mirror validation: optional attributes allow empty.
 			check.AllowEmpty = check.AllowEmpty || check.Optional
 
-			_, err := check.Validate(value, ok, vCtx)
-			if err == nil {
-				continue
-			}
-
 			ref := EntityRef{
 				RefType: RefTypeAttribute,
 				Name:    k,
 			}
 
+			problem, err := pruneValueProblem(ctx, doc, vCtx,
+				DeprecationContext{
+					Entity: &ref,
+					Block:  b,
+				}, check, value, ok)
+			if err != nil {
+				return pruneOK, nil, err
+			}
+
+			if problem == nil {
+				normalised, ok := check.normalise(value)
+				if ok {
+					setBlockAttribute(b, k, normalised)
+				}
+
+				continue
+			}
+
 			if check.AllowEmpty || check.Optional {
 				setBlockAttribute(b, k, "")
 
@@ -585,10 +748,9 @@ func pruneBlockAttributes(
 			}
 
 			// Required attribute with invalid value → can't fix.
-			return pruneRemoveMe, []ValidationResult{{
-				Entity: []EntityRef{ref},
-				Error:  err.Error(),
-			}}
+			problem.Entity = []EntityRef{ref}
+
+			return pruneRemoveMe, []ValidationResult{*problem}, nil
 		}
 	}
 
@@ -604,7 +766,7 @@ func pruneBlockAttributes(
 		}
 	}
 
-	return pruneOK, res
+	return pruneOK, res, nil
 }
 
 // pruneBlockData prunes the data map of a block. Unknown keys are deleted.
@@ -612,9 +774,10 @@ func pruneBlockAttributes(
 // values are set to "". Required keys with invalid values trigger
 // pruneRemoveMe.
 func pruneBlockData(
+	ctx context.Context, doc *newsdoc.Document,
 	b *newsdoc.Block, constraints []ConstraintMap,
 	vCtx *ValidationContext,
-) (pruneStatus, []ValidationResult) {
+) (pruneStatus, []ValidationResult, error) {
 	var res []ValidationResult
 
 	known := make(map[string]bool)
@@ -646,15 +809,28 @@ func pruneBlockData(
 				return pruneRemoveMe, []ValidationResult{{
 					Entity: []EntityRef{ref},
 					Error:  "missing required attribute",
-				}}
+				}}, nil
 			}
 
 			if !ok {
 				continue
 			}
 
-			_, err := check.Validate(value, true, vCtx)
-			if err == nil {
+			problem, err := pruneValueProblem(ctx, doc, vCtx,
+				DeprecationContext{
+					Entity: &ref,
+					Block:  b,
+				}, check, value, true)
+			if err != nil {
+				return pruneOK, nil, err
+			}
+
+			if problem == nil {
+				normalised, ok := check.normalise(value)
+				if ok {
+					b.Data[k] = normalised
+				}
+
 				continue
 			}
 
@@ -671,10 +847,9 @@ func pruneBlockData(
 			}
 
 			// Required data with invalid value → can't fix.
-			return pruneRemoveMe, []ValidationResult{{
-				Entity: []EntityRef{ref},
-				Error:  err.Error(),
-			}}
+			problem.Entity = []EntityRef{ref}
+
+			return pruneRemoveMe, []ValidationResult{*problem}, nil
 		}
 	}
 
@@ -689,16 +864,17 @@ func pruneBlockData(
 		b.Data = nil
 	}
 
-	return pruneOK, res
+	return pruneOK, res, nil
 }
 
 // pruneDocumentAttributes prunes document-level attributes. Invalid values
 // that can be cleared are set to "". Unfixable errors are reported directly
 // since there is no cascade at document level.
 func pruneDocumentAttributes(
+	ctx context.Context,
 	constraints []ConstraintMap, d *newsdoc.Document,
 	vCtx *ValidationContext,
-) []ValidationResult {
+) ([]ValidationResult, error) {
 	var res []ValidationResult
 
 	for i := range constraints {
@@ -706,28 +882,38 @@ func pruneDocumentAttributes(
 			value, ok := documentAttribute(d, k)
 			check := constraints[i].Constraints[k]
 
-			_, err := check.Validate(value, ok, vCtx)
-			if err == nil {
-				continue
-			}
-
 			ref := EntityRef{
 				RefType: RefTypeAttribute,
 				Name:    k,
 			}
 
+			problem, err := pruneValueProblem(ctx, d, vCtx,
+				DeprecationContext{Entity: &ref},
+				check, value, ok)
+			if err != nil {
+				return nil, err
+			}
+
+			if problem == nil {
+				normalised, ok := check.normalise(value)
+				if ok {
+					setDocumentAttribute(d, k, normalised)
+				}
+
+				continue
+			}
+
 			if check.AllowEmpty || check.Optional {
 				setDocumentAttribute(d, k, "")
 
 				continue
 			}
 
-			res = append(res, ValidationResult{
-				Entity: []EntityRef{ref},
-				Error:  err.Error(),
-			})
+			problem.Entity = []EntityRef{ref}
+
+			res = append(res, *problem)
 		}
 	}
 
-	return res
+	return res, nil
 }
@@ -1081,3 +1081,257 @@ func TestPruneExcessNestedBlocks(t *testing.T) {
 			doc.Content[0].Meta[0].Data["value"])
 	}
 }
+
+func deprecatedConstraints() revisor.ConstraintSet {
+	cs := simpleConstraints()
+
+	doc := &cs.Documents[0]
+
+	doc.Meta = append(doc.Meta, &revisor.BlockConstraint{
+		Declares: &revisor.BlockSignature{
+			Type: "test/old",
+		},
+		Deprecated: &revisor.Deprecation{
+			Label: "old-block",
+			Doc:   "Don't use old blocks",
+		},
+	})
+
+	doc.Content[0].Data = revisor.MakeConstraintMap(
+		map[string]revisor.StringConstraint{
+			"text": {
+				Format: revisor.StringFormatHTML,
+			},
+			"style": {
+				Optional: true,
+				Deprecated: &revisor.Deprecation{
+					Label: "text-style",
+					Doc:   "Styles are set by the client",
+				},
+			},
+		},
+	)
+
+	cs.HTMLPolicies = []revisor.HTMLPolicy{
+		{
+			Name: "default",
+			Elements: map[string]revisor.HTMLElement{
+				"strong": {},
+				"a": {
+					Attributes: revisor.MakeConstraintMap(
+						map[string]revisor.StringConstraint{
+							"href": {},
+							"target": {
+								Optional: true,
+								Deprecated: &revisor.Deprecation{
+									Label: "link-target",
+									Doc:   "Links open in the same window",
+								},
+							},
+						},
+					),
+				},
+			},
+		},
+	}
+
+	return cs
+}
+
+func enforceLabels(labels ...string) revisor.ValidationOptionFunc {
+	return revisor.WithDeprecationHandler(func(
+		_ context.Context, _ *newsdoc.Document,
+		deprecation revisor.Deprecation, _ revisor.DeprecationContext,
+	) (revisor.DeprecationDecision, error) {
+		for _, l := range labels {
+			if l == deprecation.Label {
+				return revisor.DeprecationDecision{Enforce: true}, nil
+			}
+		}
+
+		return revisor.DeprecationDecision{}, nil
+	})
+}
+
+func TestPruneEnforcedDeprecations(t *testing.T) {
+	v := newTestValidator(t, deprecatedConstraints())
+
+	doc := validDocument()
+
+	doc.Meta = append(doc.Meta, newsdoc.Block{Type: "test/old"})
+	doc.Content[0].Data["style"] = "fancy"
+	doc.Content = append(doc.Content, newsdoc.Block{
+		Type: "test/text",
+		Data: map[string]string{
+			"text": `<a href="https://example.com" target="_blank">Link</a>`,
+		},
+	})
+
+	ctx := context.Background()
+
+	res, err := v.Prune(ctx, doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 0 || len(doc.Meta) != 2 || len(doc.Content) != 2 {
+		t.Fatalf("expected deprecations to be left alone without enforcement, got: %v", res)
+	}
+
+	res, err = v.Prune(ctx, doc,
+		enforceLabels("old-block", "text-style", "link-target"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 0 {
+		t.Fatalf("expected no unfixable errors, got: %v", res)
+	}
+
+	if len(doc.Meta) != 1 || doc.Meta[0].Type != "test/meta" {
+		t.Errorf("expected the deprecated block to be removed, got: %v", doc.Meta)
+	}
+
+	if _, ok := doc.Content[0].Data["style"]; ok {
+		t.Error("expected the deprecated data value to be removed")
+	}
+
+	if len(doc.Content) != 1 {
+		t.Errorf("expected the block with deprecated HTML to be removed, got: %v",
+			doc.Content)
+	}
+
+	res, err = v.ValidateDocument(ctx, doc,
+		enforceLabels("old-block", "text-style", "link-target"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 0 {
+		t.Errorf("expected the pruned document to be valid, got: %v", res)
+	}
+}
+
+func TestPruneVariants(t *testing.T) {
+	v := newTestValidator(t, simpleConstraints()).WithVariants(
+		revisor.Variant{Name: "template"})
+
+	doc := validDocument()
+	doc.Type = "test/article#template"
+
+	res, err := v.Prune(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res) != 0 {
+		t.Errorf("expected the template document to be valid, got: %v", res)
+	}
+}
+
+func TestPruneNormaliseColour(t *testing.T) {
+	formats := []revisor.ColourFormat{
+		revisor.ColourHex, revisor.ColourShortHex, revisor.ColourHexAlpha,
+		revisor.ColourRGB, revisor.ColourRGBA, revisor.ColourHSL,
+		revisor.ColourNamed,
+	}
+
+	cs := revisor.ConstraintSet{
+		Name: "test",
+		Documents: []revisor.DocumentConstraint{
+			{
+				Declares: "test/article",
+				Meta: []*revisor.BlockConstraint{
+					{
+						Declares: &revisor.BlockSignature{
+							Type: "test/style",
+						},
+						Data: revisor.MakeConstraintMap(
+							map[string]revisor.StringConstraint{
+								"colour": {
+									Format:          revisor.StringFormatColour,
+									ColourFormats:   formats,
+									NormaliseColour: revisor.ColourHex,
+								},
+								"fill": {
+									Format:          revisor.StringFormatColour,
+									ColourFormats:   formats,
+									NormaliseColour: revisor.ColourRGBA,
+									Optional:        true,
+								},
+							},
+						),
+					},
+				},
+			},
+		},
+	}
+
+	v := newTestValidator(t, cs)
+
+	cases := []struct {
+		Colour string
+		Fill   string
+		Want   map[string]string
+	}{
+		{
+			Colour: "#FC0",
+			Fill:   "#ffcc0080",
+			Want: map[string]string{
+				"colour": "#ffcc00",
+				"fill":   "rgba(255, 204, 0, 0.5)",
+			},
+		},
+		{
+			Colour: "RebeccaPurple",
+			Fill:   "hsl(0, 100%, 50%)",
+			Want: map[string]string{
+				"colour": "#663399",
+				"fill":   "rgba(255, 0, 0, 1)",
+			},
+		},
+		{
+			// Colours with transparency can't be represented as
+			// hex and are left as is.
+			Colour: "#ffcc0080",
+			Fill:   "transparent",
+			Want: map[string]string{
+				"colour": "#ffcc0080",
+				"fill":   "rgba(0, 0, 0, 0)",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		doc := &newsdoc.Document{
+			UUID: "00000000-0000-0000-0000-000000000001",
+			Type: "test/article",
+			Meta: []newsdoc.Block{
+				{
+					Type: "test/style",
+					Data: map[string]string{
+						"colour": tc.Colour,
+						"fill":   tc.Fill,
+					},
+				},
+			},
+		}
+
+		res, err := v.Prune(context.Background(), doc)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(res) != 0 {
+			t.Errorf("expected no errors, got: %v", res)
+		}
+
+		for k, want := range tc.Want {
+			got := doc.Meta[0].Data[k]
+			if got != want {
+				t.Errorf("expected %q to be normalised to %q, got %q",
+					k, want, got)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package revisor
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,7 +24,43 @@ const (
 	StringFormatHTML    StringFormat = "html"
 	StringFormatUUID    StringFormat = "uuid"
 	StringFormatWKT     StringFormat = "wkt"
+	// StringFormatGeoJSON is a GeoJSON geometry object, f.ex.
+	// `{"type":"Point","coordinates":[18.07,59.33]}`.
+	StringFormatGeoJSON StringFormat = "geojson"
 	StringFormatColour  StringFormat = "colour"
+	// StringFormatLanguage is a BCP 47 language tag, f.ex. "sv-SE".
+	StringFormatLanguage StringFormat = "language"
+	// StringFormatURL is an absolute URL with a host, f.ex.
+	// "https://example.com/".
+	StringFormatURL StringFormat = "url"
+	// StringFormatURI is an absolute URI, f.ex. "urn:isbn:0451450523".
+	StringFormatURI StringFormat = "uri"
+	// StringFormatDuration is an ISO 8601 duration, f.ex. "PT1H30M".
+	StringFormatDuration StringFormat = "duration"
+	// StringFormatDate is an ISO 8601 calendar date, f.ex. "2024-02-29".
+	StringFormatDate StringFormat = "date"
+	// StringFormatTimeOfDay is an ISO 8601 local time of day, f.ex.
+	// "14:30" or "14:30:00".
+	StringFormatTimeOfDay StringFormat = "time-of-day"
+	// StringFormatTimeZone is an IANA time zone name, f.ex.
+	// "Europe/Stockholm".
+	StringFormatTimeZone StringFormat = "timezone"
+	// StringFormatCountry is an ISO 3166-1 alpha-2 country code, f.ex.
+	// "SE".
+	StringFormatCountry StringFormat = "country"
+	// StringFormatCountryAlpha3 is an ISO 3166-1 alpha-3 country code,
+	// f.ex. "SWE".
+	StringFormatCountryAlpha3 StringFormat = "country-alpha3"
+	// StringFormatCurrency is an ISO 4217 currency code, f.ex. "SEK".
+	StringFormatCurrency StringFormat = "currency"
+	// StringFormatEmail is an email address, f.ex. "someone@example.com".
+	StringFormatEmail StringFormat = "email"
+	// StringFormatE164 is a E.164 phone number, f.ex. "+46701234567".
+	StringFormatE164 StringFormat = "e164"
+	// StringFormatMIMEType is a MIME type, f.ex. "image/jpeg".
+	StringFormatMIMEType StringFormat = "mimetype"
+	// StringFormatJSON is a JSON value, f.ex. `{"type": "bar"}`.
+	StringFormatJSON StringFormat = "json"
 )
 
 func (f StringFormat) Describe() string {
@@ -42,8 +79,38 @@ func (f StringFormat) Describe() string {
 		return "a uuid"
 	case StringFormatWKT:
 		return "a WKT geometry"
+	case StringFormatGeoJSON:
+		return "a GeoJSON geometry"
 	case StringFormatColour:
 		return "a colour code"
+	case StringFormatLanguage:
+		return "a BCP 47 language tag"
+	case StringFormatURL:
+		return "an absolute URL"
+	case StringFormatURI:
+		return "an absolute URI"
+	case StringFormatDuration:
+		return "an ISO 8601 duration"
+	case StringFormatDate:
+		return "an ISO 8601 date"
+	case StringFormatTimeOfDay:
+		return "an ISO 8601 time of day"
+	case StringFormatTimeZone:
+		return "an IANA time zone name"
+	case StringFormatCountry:
+		return "an ISO 3166-1 alpha-2 country code"
+	case StringFormatCountryAlpha3:
+		return "an ISO 3166-1 alpha-3 country code"
+	case StringFormatCurrency:
+		return "an ISO 4217 currency code"
+	case StringFormatEmail:
+		return "an email address"
+	case StringFormatE164:
+		return "a E.164 phone number"
+	case StringFormatMIMEType:
+		return "a MIME type"
+	case StringFormatJSON:
+		return "a JSON value"
 	case StringFormatNone:
 		return ""
 	}
@@ -54,6 +121,8 @@ func (f StringFormat) Describe() string {
 type ConstraintMap struct {
 	Keys        []string
 	Constraints map[string]StringConstraint
+
+	spec specRef
 }
 
 func (cm ConstraintMap) JSONSchemaAlias() any {
@@ -79,6 +148,7 @@ func (cm ConstraintMap) Copy() ConstraintMap {
 	c := ConstraintMap{
 		Keys:        make([]string, len(cm.Keys)),
 		Constraints: make(map[string]StringConstraint, len(cm.Constraints)),
+		spec:        cm.spec,
 	}
 
 	copy(c.Keys, cm.Keys)
@@ -91,11 +161,17 @@ func (cm ConstraintMap) Copy() ConstraintMap {
 }
 
 func (cm ConstraintMap) Requirements() string {
+	return cm.RequirementsWithFormats(nil)
+}
+
+// RequirementsWithFormats describes the requirements of the constraint map,
+// using the registry to describe custom formats.
+func (cm ConstraintMap) RequirementsWithFormats(formats *FormatRegistry) string {
 	var requirements []string
 
 	for _, k := range cm.Keys {
-		requirements = append(requirements,
-			fmt.Sprintf("%s %s", k, cm.Constraints[k].Requirement()),
+		requirements = append(requirements, fmt.Sprintf("%s %s",
+			k, cm.Constraints[k].RequirementWithFormats(formats)),
 		)
 	}
 
@@ -105,7 +181,12 @@ func (cm ConstraintMap) Requirements() string {
 func (cm *ConstraintMap) UnmarshalJSON(data []byte) error {
 	var constraints map[string]StringConstraint
 
-	err := json.Unmarshal(data, &constraints)
+	// Decode strictly, the DisallowUnknownFields setting of the outer
+	// decoder doesn't carry over to custom unmarshalers.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&constraints)
 	if err != nil {
 		return fmt.Errorf("unmarshal map: %w", err)
 	}
@@ -120,21 +201,60 @@ func (cm ConstraintMap) MarshalJSON() ([]byte, error) {
 }
 
 type StringConstraint struct {
-	Name          string         `json:"name,omitempty"`
-	Description   string         `json:"description,omitempty"`
-	Optional      bool           `json:"optional,omitempty"`
-	AllowEmpty    bool           `json:"allowEmpty,omitempty"`
-	Const         *string        `json:"const,omitempty"`
-	Enum          []string       `json:"enum,omitempty"`
-	EnumRef       string         `json:"enumReference,omitempty"`
-	Pattern       *Regexp        `json:"pattern,omitempty"`
-	Glob          GlobList       `json:"glob,omitempty"`
-	Format        StringFormat   `json:"format,omitempty"`
-	Time          string         `json:"time,omitempty"`
-	Geometry      string         `json:"geometry,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Optional    bool         `json:"optional,omitempty"`
+	AllowEmpty  bool         `json:"allowEmpty,omitempty"`
+	Const       *string      `json:"const,omitempty"`
+	Enum        []string     `json:"enum,omitempty"`
+	EnumRef     string       `json:"enumReference,omitempty"`
+	Pattern     *Regexp      `json:"pattern,omitempty"`
+	Glob        GlobList     `json:"glob,omitempty"`
+	Format      StringFormat `json:"format,omitempty"`
+	Time        string       `json:"time,omitempty"`
+	Geometry    string       `json:"geometry,omitempty"`
+	// WGS84 requires geometry coordinates to be valid WGS84 longitudes
+	// and latitudes.
+	WGS84 bool `json:"wgs84,omitempty"`
+	// ValidRings requires polygon rings to be closed and to not
+	// intersect themselves.
+	ValidRings bool `json:"validRings,omitempty"`
+	// MinPoints is the minimum number of points in a geometry.
+	MinPoints int `json:"minPoints,omitempty"`
+	// BoundingBox requires all points of a geometry to be within the
+	// bounding box.
+	BoundingBox   *BoundingBox   `json:"boundingBox,omitempty"`
 	ColourFormats []ColourFormat `json:"colourFormats,omitempty"`
-	HTMLPolicy    string         `json:"htmlPolicy,omitempty"`
-	Deprecated    *Deprecation   `json:"deprecated,omitempty"`
+	// NormaliseColour is the colour format that Prune converts colours
+	// to, it must be one of the allowed colour formats.
+	NormaliseColour ColourFormat `json:"normaliseColour,omitempty"`
+	// Languages restricts language tags to the given base languages.
+	Languages []string `json:"languages,omitempty"`
+	// RequireRegion requires language tags to have a region.
+	RequireRegion bool `json:"requireRegion,omitempty"`
+	// Canonical requires values to be in their canonical form, f.ex.
+	// "en-US" rather than "en-us" for language tags.
+	Canonical bool `json:"canonical,omitempty"`
+	// Schemes restricts URLs and URIs to the given schemes.
+	Schemes []string `json:"schemes,omitempty"`
+	// Hosts restricts URLs and URIs to hosts that match one of the glob
+	// patterns.
+	Hosts GlobList `json:"hosts,omitempty"`
+	// AllowQuery allows URLs and URIs to have a query.
+	AllowQuery bool `json:"allowQuery,omitempty"`
+	// AllowFragment allows URLs and URIs to have a fragment.
+	AllowFragment bool `json:"allowFragment,omitempty"`
+	// MinDuration is the shortest allowed duration.
+	MinDuration *ISODuration `json:"minDuration,omitempty"`
+	// MaxDuration is the longest allowed duration.
+	MaxDuration *ISODuration `json:"maxDuration,omitempty"`
+	// MIMETypes restricts MIME types to types that match one of the glob
+	// patterns, f.ex. "image/*".
+	MIMETypes GlobList `json:"mimeTypes,omitempty"`
+	// JSONSchema is used to validate JSON values.
+	JSONSchema *JSONValueSchema `json:"jsonSchema,omitempty"`
+	HTMLPolicy string           `json:"htmlPolicy,omitempty"`
+	Deprecated *Deprecation     `json:"deprecated,omitempty"`
 
 	// Labels (and hints) are not constraints per se, but should be seen as
 	// labels on the value that can be used by systems that process data
@@ -144,6 +264,12 @@ type StringConstraint struct {
 }
 
 func (sc StringConstraint) Requirement() string {
+	return sc.RequirementWithFormats(nil)
+}
+
+// RequirementWithFormats describes the requirements of the constraint, using
+// the registry to describe custom formats.
+func (sc StringConstraint) RequirementWithFormats(formats *FormatRegistry) string {
 	var reqs []string
 
 	if sc.Const != nil {
@@ -175,7 +301,7 @@ func (sc StringConstraint) Requirement() string {
 	}
 
 	if sc.Format != StringFormatNone {
-		reqs = append(reqs, fmt.Sprintf("is a %s", sc.Format.Describe()))
+		reqs = append(reqs, "is "+formats.Describe(sc.Format))
 	}
 
 	return strings.Join(reqs, " and ")
@@ -184,7 +310,13 @@ func (sc StringConstraint) Requirement() string {
 type ValidationContext struct {
 	coll     ValueCollector
 	depr     DeprecationHandlerFunc
+	cov      *CoverageCollector
 	variants []Variant
+	formats  *FormatRegistry
+
+	// htmlDeprecations returns the deprecated HTML attributes and
+	// attribute values of a HTML value.
+	htmlDeprecations func(policyName, value string) ([]HTMLAttributeDeprecation, error)
 
 	ValidateHTML func(policyName, value string) error
 	ValidateEnum func(enum string, value string) (*Deprecation, error)
@@ -225,6 +357,10 @@ func (sc *StringConstraint) Validate(
 	var deprecation *Deprecation
 
 	if sc.EnumRef != "" {
+		if vCtx == nil || vCtx.ValidateEnum == nil {
+			return nil, errors.New("enum validation is not available in this context")
+		}
+
 		depr, err := vCtx.ValidateEnum(sc.EnumRef, value)
 		if err != nil {
 			return nil, err
@@ -282,17 +418,102 @@ func (sc *StringConstraint) Validate(
 			return nil, errors.New("invalid uuid value")
 		}
 	case StringFormatWKT:
-		err := validateWKT(sc.Geometry, value)
+		err := validateWKT(value, sc)
 		if err != nil {
 			return nil, fmt.Errorf("WKT validation: %w", err)
 		}
+	case StringFormatGeoJSON:
+		err := validateGeoJSON(value, sc)
+		if err != nil {
+			return nil, fmt.Errorf("GeoJSON validation: %w", err)
+		}
 	case StringFormatColour:
 		err := validateColour(value, sc.ColourFormats)
 		if err != nil {
 			return nil, fmt.Errorf("invalid colour value %q: %w", value, err)
 		}
+	case StringFormatLanguage:
+		err := validateLanguageTag(value, sc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language tag %q: %w", value, err)
+		}
+	case StringFormatURL:
+		err := validateURL(value, sc, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %w", value, err)
+		}
+	case StringFormatURI:
+		err := validateURL(value, sc, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI %q: %w", value, err)
+		}
+	case StringFormatDuration:
+		err := validateDuration(value, sc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+	case StringFormatDate:
+		err := validateDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", value, err)
+		}
+	case StringFormatTimeOfDay:
+		err := validateTimeOfDay(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time of day %q: %w", value, err)
+		}
+	case StringFormatTimeZone:
+		err := validateTimeZone(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", value, err)
+		}
+	case StringFormatCountry, StringFormatCountryAlpha3:
+		err := validateCountryCode(value, sc.Format == StringFormatCountryAlpha3)
+		if err != nil {
+			return nil, fmt.Errorf("invalid country code %q: %w", value, err)
+		}
+	case StringFormatCurrency:
+		err := validateCurrencyCode(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency code %q: %w", value, err)
+		}
+	case StringFormatEmail:
+		err := validateEmail(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email address %q: %w", value, err)
+		}
+	case StringFormatE164:
+		err := validateE164(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid E.164 phone number %q: %w", value, err)
+		}
+	case StringFormatMIMEType:
+		err := validateMIMEType(value, sc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MIME type %q: %w", value, err)
+		}
+	case StringFormatJSON:
+		err := validateJSONValue(value, sc.JSONSchema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON value: %w", err)
+		}
 	default:
-		return nil, fmt.Errorf("unknown string format %q", sc.Format)
+		var formats *FormatRegistry
+
+		if vCtx != nil {
+			formats = vCtx.formats
+		}
+
+		custom, ok := formats.Lookup(sc.Format)
+		if !ok {
+			return nil, fmt.Errorf("unknown string format %q", sc.Format)
+		}
+
+		err := custom.Validate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w",
+				sc.Format, value, err)
+		}
 	}
 
 	if !sc.AllowEmpty && value == "" {
@@ -0,0 +1,603 @@
+package revisor
+
+// timeZoneNames contains the zone and link names of the IANA time zone
+// database, version 2025b, except for the "Factory" placeholder zone.
+var timeZoneNames = map[string]bool{
+	"Africa/Abidjan":                   true,
+	"Africa/Accra":                     true,
+	"Africa/Addis_Ababa":               true,
+	"Africa/Algiers":                   true,
+	"Africa/Asmara":                    true,
+	"Africa/Asmera":                    true,
+	"Africa/Bamako":                    true,
+	"Africa/Bangui":                    true,
+	"Africa/Banjul":                    true,
+	"Africa/Bissau":                    true,
+	"Africa/Blantyre":                  true,
+	"Africa/Brazzaville":               true,
+	"Africa/Bujumbura":                 true,
+	"Africa/Cairo":                     true,
+	"Africa/Casablanca":                true,
+	"Africa/Ceuta":                     true,
+	"Africa/Conakry":                   true,
+	"Africa/Dakar":                     true,
+	"Africa/Dar_es_Salaam":             true,
+	"Africa/Djibouti":                  true,
+	"Africa/Douala":                    true,
+	"Africa/El_Aaiun":                  true,
+	"Africa/Freetown":                  true,
+	"Africa/Gaborone":                  true,
+	"Africa/Harare":                    true,
+	"Africa/Johannesburg":              true,
+	"Africa/Juba":                      true,
+	"Africa/Kampala":                   true,
+	"Africa/Khartoum":                  true,
+	"Africa/Kigali":                    true,
+	"Africa/Kinshasa":                  true,
+	"Africa/Lagos":                     true,
+	"Africa/Libreville":                true,
+	"Africa/Lome":                      true,
+	"Africa/Luanda":                    true,
+	"Africa/Lubumbashi":                true,
+	"Africa/Lusaka":                    true,
+	"Africa/Malabo":                    true,
+	"Africa/Maputo":                    true,
+	"Africa/Maseru":                    true,
+	"Africa/Mbabane":                   true,
+	"Africa/Mogadishu":                 true,
+	"Africa/Monrovia":                  true,
+	"Africa/Nairobi":                   true,
+	"Africa/Ndjamena":                  true,
+	"Africa/Niamey":                    true,
+	"Africa/Nouakchott":                true,
+	"Africa/Ouagadougou":               true,
+	"Africa/Porto-Novo":                true,
+	"Africa/Sao_Tome":                  true,
+	"Africa/Timbuktu":                  true,
+	"Africa/Tripoli":                   true,
+	"Africa/Tunis":                     true,
+	"Africa/Windhoek":                  true,
+	"America/Adak":                     true,
+	"America/Anchorage":                true,
+	"America/Anguilla":                 true,
+	"America/Antigua":                  true,
+	"America/Araguaina":                true,
+	"America/Argentina/Buenos_Aires":   true,
+	"America/Argentina/Catamarca":      true,
+	"America/Argentina/ComodRivadavia": true,
+	"America/Argentina/Cordoba":        true,
+	"America/Argentina/Jujuy":          true,
+	"America/Argentina/La_Rioja":       true,
+	"America/Argentina/Mendoza":        true,
+	"America/Argentina/Rio_Gallegos":   true,
+	"America/Argentina/Salta":          true,
+	"America/Argentina/San_Juan":       true,
+	"America/Argentina/San_Luis":       true,
+	"America/Argentina/Tucuman":        true,
+	"America/Argentina/Ushuaia":        true,
+	"America/Aruba":                    true,
+	"America/Asuncion":                 true,
+	"America/Atikokan":                 true,
+	"America/Atka":                     true,
+	"America/Bahia":                    true,
+	"America/Bahia_Banderas":           true,
+	"America/Barbados":                 true,
+	"America/Belem":                    true,
+	"America/Belize":                   true,
+	"America/Blanc-Sablon":             true,
+	"America/Boa_Vista":                true,
+	"America/Bogota":                   true,
+	"America/Boise":                    true,
+	"America/Buenos_Aires":             true,
+	"America/Cambridge_Bay":            true,
+	"America/Campo_Grande":             true,
+	"America/Cancun":                   true,
+	"America/Caracas":                  true,
+	"America/Catamarca":                true,
+	"America/Cayenne":                  true,
+	"America/Cayman":                   true,
+	"America/Chicago":                  true,
+	"America/Chihuahua":                true,
+	"America/Ciudad_Juarez":            true,
+	"America/Coral_Harbour":            true,
+	"America/Cordoba":                  true,
+	"America/Costa_Rica":               true,
+	"America/Coyhaique":                true,
+	"America/Creston":                  true,
+	"America/Cuiaba":                   true,
+	"America/Curacao":                  true,
+	"America/Danmarkshavn":             true,
+	"America/Dawson":                   true,
+	"America/Dawson_Creek":             true,
+	"America/Denver":                   true,
+	"America/Detroit":                  true,
+	"America/Dominica":                 true,
+	"America/Edmonton":                 true,
+	"America/Eirunepe":                 true,
+	"America/El_Salvador":              true,
+	"America/Ensenada":                 true,
+	"America/Fort_Nelson":              true,
+	"America/Fort_Wayne":               true,
+	"America/Fortaleza":                true,
+	"America/Glace_Bay":                true,
+	"America/Godthab":                  true,
+	"America/Goose_Bay":                true,
+	"America/Grand_Turk":               true,
+	"America/Grenada":                  true,
+	"America/Guadeloupe":               true,
+	"America/Guatemala":                true,
+	"America/Guayaquil":                true,
+	"America/Guyana":                   true,
+	"America/Halifax":                  true,
+	"America/Havana":                   true,
+	"America/Hermosillo":               true,
+	"America/Indiana/Indianapolis":     true,
+	"America/Indiana/Knox":             true,
+	"America/Indiana/Marengo":          true,
+	"America/Indiana/Petersburg":       true,
+	"America/Indiana/Tell_City":        true,
+	"America/Indiana/Vevay":            true,
+	"America/Indiana/Vincennes":        true,
+	"America/Indiana/Winamac":          true,
+	"America/Indianapolis":             true,
+	"America/Inuvik":                   true,
+	"America/Iqaluit":                  true,
+	"America/Jamaica":                  true,
+	"America/Jujuy":                    true,
+	"America/Juneau":                   true,
+	"America/Kentucky/Louisville":      true,
+	"America/Kentucky/Monticello":      true,
+	"America/Knox_IN":                  true,
+	"America/Kralendijk":               true,
+	"America/La_Paz":                   true,
+	"America/Lima":                     true,
+	"America/Los_Angeles":              true,
+	"America/Louisville":               true,
+	"America/Lower_Princes":            true,
+	"America/Maceio":                   true,
+	"America/Managua":                  true,
+	"America/Manaus":                   true,
+	"America/Marigot":                  true,
+	"America/Martinique":               true,
+	"America/Matamoros":                true,
+	"America/Mazatlan":                 true,
+	"America/Mendoza":                  true,
+	"America/Menominee":                true,
+	"America/Merida":                   true,
+	"America/Metlakatla":               true,
+	"America/Mexico_City":              true,
+	"America/Miquelon":                 true,
+	"America/Moncton":                  true,
+	"America/Monterrey":                true,
+	"America/Montevideo":               true,
+	"America/Montreal":                 true,
+	"America/Montserrat":               true,
+	"America/Nassau":                   true,
+	"America/New_York":                 true,
+	"America/Nipigon":                  true,
+	"America/Nome":                     true,
+	"America/Noronha":                  true,
+	"America/North_Dakota/Beulah":      true,
+	"America/North_Dakota/Center":      true,
+	"America/North_Dakota/New_Salem":   true,
+	"America/Nuuk":                     true,
+	"America/Ojinaga":                  true,
+	"America/Panama":                   true,
+	"America/Pangnirtung":              true,
+	"America/Paramaribo":               true,
+	"America/Phoenix":                  true,
+	"America/Port-au-Prince":           true,
+	"America/Port_of_Spain":            true,
+	"America/Porto_Acre":               true,
+	"America/Porto_Velho":              true,
+	"America/Puerto_Rico":              true,
+	"America/Punta_Arenas":             true,
+	"America/Rainy_River":              true,
+	"America/Rankin_Inlet":             true,
+	"America/Recife":                   true,
+	"America/Regina":                   true,
+	"America/Resolute":                 true,
+	"America/Rio_Branco":               true,
+	"America/Rosario":                  true,
+	"America/Santa_Isabel":             true,
+	"America/Santarem":                 true,
+	"America/Santiago":                 true,
+	"America/Santo_Domingo":            true,
+	"America/Sao_Paulo":                true,
+	"America/Scoresbysund":             true,
+	"America/Shiprock":                 true,
+	"America/Sitka":                    true,
+	"America/St_Barthelemy":            true,
+	"America/St_Johns":                 true,
+	"America/St_Kitts":                 true,
+	"America/St_Lucia":                 true,
+	"America/St_Thomas":                true,
+	"America/St_Vincent":               true,
+	"America/Swift_Current":            true,
+	"America/Tegucigalpa":              true,
+	"America/Thule":                    true,
+	"America/Thunder_Bay":              true,
+	"America/Tijuana":                  true,
+	"America/Toronto":                  true,
+	"America/Tortola":                  true,
+	"America/Vancouver":                true,
+	"America/Virgin":                   true,
+	"America/Whitehorse":               true,
+	"America/Winnipeg":                 true,
+	"America/Yakutat":                  true,
+	"America/Yellowknife":              true,
+	"Antarctica/Casey":                 true,
+	"Antarctica/Davis":                 true,
+	"Antarctica/DumontDUrville":        true,
+	"Antarctica/Macquarie":             true,
+	"Antarctica/Mawson":                true,
+	"Antarctica/McMurdo":               true,
+	"Antarctica/Palmer":                true,
+	"Antarctica/Rothera":               true,
+	"Antarctica/South_Pole":            true,
+	"Antarctica/Syowa":                 true,
+	"Antarctica/Troll":                 true,
+	"Antarctica/Vostok":                true,
+	"Arctic/Longyearbyen":              true,
+	"Asia/Aden":                        true,
+	"Asia/Almaty":                      true,
+	"Asia/Amman":                       true,
+	"Asia/Anadyr":                      true,
+	"Asia/Aqtau":                       true,
+	"Asia/Aqtobe":                      true,
+	"Asia/Ashgabat":                    true,
+	"Asia/Ashkhabad":                   true,
+	"Asia/Atyrau":                      true,
+	"Asia/Baghdad":                     true,
+	"Asia/Bahrain":                     true,
+	"Asia/Baku":                        true,
+	"Asia/Bangkok":                     true,
+	"Asia/Barnaul":                     true,
+	"Asia/Beirut":                      true,
+	"Asia/Bishkek":                     true,
+	"Asia/Brunei":                      true,
+	"Asia/Calcutta":                    true,
+	"Asia/Chita":                       true,
+	"Asia/Choibalsan":                  true,
+	"Asia/Chongqing":                   true,
+	"Asia/Chungking":                   true,
+	"Asia/Colombo":                     true,
+	"Asia/Dacca":                       true,
+	"Asia/Damascus":                    true,
+	"Asia/Dhaka":                       true,
+	"Asia/Dili":                        true,
+	"Asia/Dubai":                       true,
+	"Asia/Dushanbe":                    true,
+	"Asia/Famagusta":                   true,
+	"Asia/Gaza":                        true,
+	"Asia/Harbin":                      true,
+	"Asia/Hebron":                      true,
+	"Asia/Ho_Chi_Minh":                 true,
+	"Asia/Hong_Kong":                   true,
+	"Asia/Hovd":                        true,
+	"Asia/Irkutsk":                     true,
+	"Asia/Istanbul":                    true,
+	"Asia/Jakarta":                     true,
+	"Asia/Jayapura":                    true,
+	"Asia/Jerusalem":                   true,
+	"Asia/Kabul":                       true,
+	"Asia/Kamchatka":                   true,
+	"Asia/Karachi":                     true,
+	"Asia/Kashgar":                     true,
+	"Asia/Kathmandu":                   true,
+	"Asia/Katmandu":                    true,
+	"Asia/Khandyga":                    true,
+	"Asia/Kolkata":                     true,
+	"Asia/Krasnoyarsk":                 true,
+	"Asia/Kuala_Lumpur":                true,
+	"Asia/Kuching":                     true,
+	"Asia/Kuwait":                      true,
+	"Asia/Macao":                       true,
+	"Asia/Macau":                       true,
+	"Asia/Magadan":                     true,
+	"Asia/Makassar":                    true,
+	"Asia/Manila":                      true,
+	"Asia/Muscat":                      true,
+	"Asia/Nicosia":                     true,
+	"Asia/Novokuznetsk":                true,
+	"Asia/Novosibirsk":                 true,
+	"Asia/Omsk":                        true,
+	"Asia/Oral":                        true,
+	"Asia/Phnom_Penh":                  true,
+	"Asia/Pontianak":                   true,
+	"Asia/Pyongyang":                   true,
+	"Asia/Qatar":                       true,
+	"Asia/Qostanay":                    true,
+	"Asia/Qyzylorda":                   true,
+	"Asia/Rangoon":                     true,
+	"Asia/Riyadh":                      true,
+	"Asia/Saigon":                      true,
+	"Asia/Sakhalin":                    true,
+	"Asia/Samarkand":                   true,
+	"Asia/Seoul":                       true,
+	"Asia/Shanghai":                    true,
+	"Asia/Singapore":                   true,
+	"Asia/Srednekolymsk":               true,
+	"Asia/Taipei":                      true,
+	"Asia/Tashkent":                    true,
+	"Asia/Tbilisi":                     true,
+	"Asia/Tehran":                      true,
+	"Asia/Tel_Aviv":                    true,
+	"Asia/Thimbu":                      true,
+	"Asia/Thimphu":                     true,
+	"Asia/Tokyo":                       true,
+	"Asia/Tomsk":                       true,
+	"Asia/Ujung_Pandang":               true,
+	"Asia/Ulaanbaatar":                 true,
+	"Asia/Ulan_Bator":                  true,
+	"Asia/Urumqi":                      true,
+	"Asia/Ust-Nera":                    true,
+	"Asia/Vientiane":                   true,
+	"Asia/Vladivostok":                 true,
+	"Asia/Yakutsk":                     true,
+	"Asia/Yangon":                      true,
+	"Asia/Yekaterinburg":               true,
+	"Asia/Yerevan":                     true,
+	"Atlantic/Azores":                  true,
+	"Atlantic/Bermuda":                 true,
+	"Atlantic/Canary":                  true,
+	"Atlantic/Cape_Verde":              true,
+	"Atlantic/Faeroe":                  true,
+	"Atlantic/Faroe":                   true,
+	"Atlantic/Jan_Mayen":               true,
+	"Atlantic/Madeira":                 true,
+	"Atlantic/Reykjavik":               true,
+	"Atlantic/South_Georgia":           true,
+	"Atlantic/St_Helena":               true,
+	"Atlantic/Stanley":                 true,
+	"Australia/ACT":                    true,
+	"Australia/Adelaide":               true,
+	"Australia/Brisbane":               true,
+	"Australia/Broken_Hill":            true,
+	"Australia/Canberra":               true,
+	"Australia/Currie":                 true,
+	"Australia/Darwin":                 true,
+	"Australia/Eucla":                  true,
+	"Australia/Hobart":                 true,
+	"Australia/LHI":                    true,
+	"Australia/Lindeman":               true,
+	"Australia/Lord_Howe":              true,
+	"Australia/Melbourne":              true,
+	"Australia/NSW":                    true,
+	"Australia/North":                  true,
+	"Australia/Perth":                  true,
+	"Australia/Queensland":             true,
+	"Australia/South":                  true,
+	"Australia/Sydney":                 true,
+	"Australia/Tasmania":               true,
+	"Australia/Victoria":               true,
+	"Australia/West":                   true,
+	"Australia/Yancowinna":             true,
+	"Brazil/Acre":                      true,
+	"Brazil/DeNoronha":                 true,
+	"Brazil/East":                      true,
+	"Brazil/West":                      true,
+	"CET":                              true,
+	"CST6CDT":                          true,
+	"Canada/Atlantic":                  true,
+	"Canada/Central":                   true,
+	"Canada/Eastern":                   true,
+	"Canada/Mountain":                  true,
+	"Canada/Newfoundland":              true,
+	"Canada/Pacific":                   true,
+	"Canada/Saskatchewan":              true,
+	"Canada/Yukon":                     true,
+	"Chile/Continental":                true,
+	"Chile/EasterIsland":               true,
+	"Cuba":                             true,
+	"EET":                              true,
+	"EST":                              true,
+	"EST5EDT":                          true,
+	"Egypt":                            true,
+	"Eire":                             true,
+	"Etc/GMT":                          true,
+	"Etc/GMT+0":                        true,
+	"Etc/GMT+1":                        true,
+	"Etc/GMT+10":                       true,
+	"Etc/GMT+11":                       true,
+	"Etc/GMT+12":                       true,
+	"Etc/GMT+2":                        true,
+	"Etc/GMT+3":                        true,
+	"Etc/GMT+4":                        true,
+	"Etc/GMT+5":                        true,
+	"Etc/GMT+6":                        true,
+	"Etc/GMT+7":                        true,
+	"Etc/GMT+8":                        true,
+	"Etc/GMT+9":                        true,
+	"Etc/GMT-0":                        true,
+	"Etc/GMT-1":                        true,
+	"Etc/GMT-10":                       true,
+	"Etc/GMT-11":                       true,
+	"Etc/GMT-12":                       true,
+	"Etc/GMT-13":                       true,
+	"Etc/GMT-14":                       true,
+	"Etc/GMT-2":                        true,
+	"Etc/GMT-3":                        true,
+	"Etc/GMT-4":                        true,
+	"Etc/GMT-5":                        true,
+	"Etc/GMT-6":                        true,
+	"Etc/GMT-7":                        true,
+	"Etc/GMT-8":                        true,
+	"Etc/GMT-9":                        true,
+	"Etc/GMT0":                         true,
+	"Etc/Greenwich":                    true,
+	"Etc/UCT":                          true,
+	"Etc/UTC":                          true,
+	"Etc/Universal":                    true,
+	"Etc/Zulu":                         true,
+	"Europe/Amsterdam":                 true,
+	"Europe/Andorra":                   true,
+	"Europe/Astrakhan":                 true,
+	"Europe/Athens":                    true,
+	"Europe/Belfast":                   true,
+	"Europe/Belgrade":                  true,
+	"Europe/Berlin":                    true,
+	"Europe/Bratislava":                true,
+	"Europe/Brussels":                  true,
+	"Europe/Bucharest":                 true,
+	"Europe/Budapest":                  true,
+	"Europe/Busingen":                  true,
+	"Europe/Chisinau":                  true,
+	"Europe/Copenhagen":                true,
+	"Europe/Dublin":                    true,
+	"Europe/Gibraltar":                 true,
+	"Europe/Guernsey":                  true,
+	"Europe/Helsinki":                  true,
+	"Europe/Isle_of_Man":               true,
+	"Europe/Istanbul":                  true,
+	"Europe/Jersey":                    true,
+	"Europe/Kaliningrad":               true,
+	"Europe/Kiev":                      true,
+	"Europe/Kirov":                     true,
+	"Europe/Kyiv":                      true,
+	"Europe/Lisbon":                    true,
+	"Europe/Ljubljana":                 true,
+	"Europe/London":                    true,
+	"Europe/Luxembourg":                true,
+	"Europe/Madrid":                    true,
+	"Europe/Malta":                     true,
+	"Europe/Mariehamn":                 true,
+	"Europe/Minsk":                     true,
+	"Europe/Monaco":                    true,
+	"Europe/Moscow":                    true,
+	"Europe/Nicosia":                   true,
+	"Europe/Oslo":                      true,
+	"Europe/Paris":                     true,
+	"Europe/Podgorica":                 true,
+	"Europe/Prague":                    true,
+	"Europe/Riga":                      true,
+	"Europe/Rome":                      true,
+	"Europe/Samara":                    true,
+	"Europe/San_Marino":                true,
+	"Europe/Sarajevo":                  true,
+	"Europe/Saratov":                   true,
+	"Europe/Simferopol":                true,
+	"Europe/Skopje":                    true,
+	"Europe/Sofia":                     true,
+	"Europe/Stockholm":                 true,
+	"Europe/Tallinn":                   true,
+	"Europe/Tirane":                    true,
+	"Europe/Tiraspol":                  true,
+	"Europe/Ulyanovsk":                 true,
+	"Europe/Uzhgorod":                  true,
+	"Europe/Vaduz":                     true,
+	"Europe/Vatican":                   true,
+	"Europe/Vienna":                    true,
+	"Europe/Vilnius":                   true,
+	"Europe/Volgograd":                 true,
+	"Europe/Warsaw":                    true,
+	"Europe/Zagreb":                    true,
+	"Europe/Zaporozhye":                true,
+	"Europe/Zurich":                    true,
+	"GB":                               true,
+	"GB-Eire":                          true,
+	"GMT":                              true,
+	"GMT+0":                            true,
+	"GMT-0":                            true,
+	"GMT0":                             true,
+	"Greenwich":                        true,
+	"HST":                              true,
+	"Hongkong":                         true,
+	"Iceland":                          true,
+	"Indian/Antananarivo":              true,
+	"Indian/Chagos":                    true,
+	"Indian/Christmas":                 true,
+	"Indian/Cocos":                     true,
+	"Indian/Comoro":                    true,
+	"Indian/Kerguelen":                 true,
+	"Indian/Mahe":                      true,
+	"Indian/Maldives":                  true,
+	"Indian/Mauritius":                 true,
+	"Indian/Mayotte":                   true,
+	"Indian/Reunion":                   true,
+	"Iran":                             true,
+	"Israel":                           true,
+	"Jamaica":                          true,
+	"Japan":                            true,
+	"Kwajalein":                        true,
+	"Libya":                            true,
+	"MET":                              true,
+	"MST":                              true,
+	"MST7MDT":                          true,
+	"Mexico/BajaNorte":                 true,
+	"Mexico/BajaSur":                   true,
+	"Mexico/General":                   true,
+	"NZ":                               true,
+	"NZ-CHAT":                          true,
+	"Navajo":                           true,
+	"PRC":                              true,
+	"PST8PDT":                          true,
+	"Pacific/Apia":                     true,
+	"Pacific/Auckland":                 true,
+	"Pacific/Bougainville":             true,
+	"Pacific/Chatham":                  true,
+	"Pacific/Chuuk":                    true,
+	"Pacific/Easter":                   true,
+	"Pacific/Efate":                    true,
+	"Pacific/Enderbury":                true,
+	"Pacific/Fakaofo":                  true,
+	"Pacific/Fiji":                     true,
+	"Pacific/Funafuti":                 true,
+	"Pacific/Galapagos":                true,
+	"Pacific/Gambier":                  true,
+	"Pacific/Guadalcanal":              true,
+	"Pacific/Guam":                     true,
+	"Pacific/Honolulu":                 true,
+	"Pacific/Johnston":                 true,
+	"Pacific/Kanton":                   true,
+	"Pacific/Kiritimati":               true,
+	"Pacific/Kosrae":                   true,
+	"Pacific/Kwajalein":                true,
+	"Pacific/Majuro":                   true,
+	"Pacific/Marquesas":                true,
+	"Pacific/Midway":                   true,
+	"Pacific/Nauru":                    true,
+	"Pacific/Niue":                     true,
+	"Pacific/Norfolk":                  true,
+	"Pacific/Noumea":                   true,
+	"Pacific/Pago_Pago":                true,
+	"Pacific/Palau":                    true,
+	"Pacific/Pitcairn":                 true,
+	"Pacific/Pohnpei":                  true,
+	"Pacific/Ponape":                   true,
+	"Pacific/Port_Moresby":             true,
+	"Pacific/Rarotonga":                true,
+	"Pacific/Saipan":                   true,
+	"Pacific/Samoa":                    true,
+	"Pacific/Tahiti":                   true,
+	"Pacific/Tarawa":                   true,
+	"Pacific/Tongatapu":                true,
+	"Pacific/Truk":                     true,
+	"Pacific/Wake":                     true,
+	"Pacific/Wallis":                   true,
+	"Pacific/Yap":                      true,
+	"Poland":                           true,
+	"Portugal":                         true,
+	"ROC":                              true,
+	"ROK":                              true,
+	"Singapore":                        true,
+	"Turkey":                           true,
+	"UCT":                              true,
+	"US/Alaska":                        true,
+	"US/Aleutian":                      true,
+	"US/Arizona":                       true,
+	"US/Central":                       true,
+	"US/East-Indiana":                  true,
+	"US/Eastern":                       true,
+	"US/Hawaii":                        true,
+	"US/Indiana-Starke":                true,
+	"US/Michigan":                      true,
+	"US/Mountain":                      true,
+	"US/Pacific":                       true,
+	"US/Samoa":                         true,
+	"UTC":                              true,
+	"Universal":                        true,
+	"W-SU":                             true,
+	"WET":                              true,
+	"Zulu":                             true,
+}
@@ -0,0 +1,63 @@
+package revisor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// validateURL validates an absolute URI against the URL options of the
+// constraint. If requireHost is true the URI must be a hierarchical URL with a
+// host, f.ex. "https://example.com/", rather than f.ex. "urn:isbn:0451450523".
+func validateURL(value string, sc *StringConstraint, requireHost bool) error {
+	// The url package is lenient and accepts whitespace that isn't
+	// allowed in URIs.
+	if strings.ContainsFunc(value, func(r rune) bool {
+		return r <= ' ' || r == 0x7f
+	}) {
+		return errors.New("contains whitespace or control characters")
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		var uErr *url.Error
+		if errors.As(err, &uErr) {
+			err = uErr.Err
+		}
+
+		return err
+	}
+
+	if u.Scheme == "" {
+		return errors.New("missing scheme")
+	}
+
+	if len(sc.Schemes) > 0 && !slices.ContainsFunc(sc.Schemes, func(s string) bool {
+		return strings.EqualFold(s, u.Scheme)
+	}) {
+		return fmt.Errorf("the scheme must be one of: %s",
+			strings.Join(sc.Schemes, ", "))
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	if requireHost && (u.Opaque != "" || host == "") {
+		return errors.New("missing host")
+	}
+
+	if len(sc.Hosts) > 0 && (host == "" || !sc.Hosts.MatchOrEmpty(host)) {
+		return fmt.Errorf("the host %s", sc.Hosts.String())
+	}
+
+	if !sc.AllowQuery && (u.RawQuery != "" || u.ForceQuery) {
+		return errors.New("a query is not allowed")
+	}
+
+	if !sc.AllowFragment && strings.Contains(value, "#") {
+		return errors.New("a fragment is not allowed")
+	}
+
+	return nil
+}
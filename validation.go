@@ -1,13 +1,14 @@
 package revisor
 
 import (
-	"bytes"
 	"context"
 	"embed"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ttab/newsdoc"
@@ -21,6 +22,8 @@ type Validator struct {
 	documents    []*DocumentConstraint
 	htmlPolicies map[string]*HTMLPolicy
 	enums        *enumSet
+	migrations   []*DocumentMigration
+	formats      *FormatRegistry
 }
 
 func NewValidator(
@@ -47,19 +50,24 @@ func NewValidator(
 				constraint.Name, err)
 		}
 
-		err = collectBlockDeclarations(v.blocks, BlockKindLink, constraint.Links)
+		setRef := specRef{set: constraint.Name}
+
+		err = collectBlockDeclarations(v.blocks, BlockKindLink,
+			setRef, constraint.Links)
 		if err != nil {
 			return nil, fmt.Errorf("constraint set %q links: %w",
 				constraint.Name, err)
 		}
 
-		err = collectBlockDeclarations(v.blocks, BlockKindMeta, constraint.Meta)
+		err = collectBlockDeclarations(v.blocks, BlockKindMeta,
+			setRef, constraint.Meta)
 		if err != nil {
 			return nil, fmt.Errorf("constraint set %q meta blocks: %w",
 				constraint.Name, err)
 		}
 
-		err = collectBlockDeclarations(v.blocks, BlockKindContent, constraint.Content)
+		err = collectBlockDeclarations(v.blocks, BlockKindContent,
+			setRef, constraint.Content)
 		if err != nil {
 			return nil, fmt.Errorf("constraint set %q content blocks: %w",
 				constraint.Name, err)
@@ -68,6 +76,15 @@ func NewValidator(
 		for j := range constraint.Documents {
 			doc := constraint.Documents[j]
 
+			// Work on copies so that resolving references doesn't
+			// modify the constraint set.
+			doc.Links = bsListCopy(doc.Links)
+			doc.Meta = bsListCopy(doc.Meta)
+			doc.Content = bsListCopy(doc.Content)
+			doc.Attributes = doc.Attributes.Copy()
+
+			doc.setSpecRef(setRef.child("documents", strconv.Itoa(j)))
+
 			v.documents = append(v.documents, &doc)
 
 			if doc.Declares == "" {
@@ -88,16 +105,27 @@ func NewValidator(
 				constraint.Name, err)
 		}
 
-		for _, e := range constraint.Enums {
-			err := v.enums.Register(e)
+		for j, e := range constraint.Enums {
+			err := v.enums.Register(
+				setRef.child("enums", strconv.Itoa(j)), e)
 			if err != nil {
 				return nil, fmt.Errorf("failed to add enum for %q: %w",
 					constraint.Name, err)
 			}
 		}
+
+		err = v.collectMigrations(constraint)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err := v.resolveDocumentBlockRefs()
+	err := checkImports(constraints)
+	if err != nil {
+		return nil, fmt.Errorf("invalid imports: %w", err)
+	}
+
+	err = v.resolveDocumentBlockRefs()
 	if err != nil {
 		return nil, fmt.Errorf("invalid block reference: %w", err)
 	}
@@ -107,6 +135,11 @@ func NewValidator(
 		return nil, fmt.Errorf("invalid enums: %w", err)
 	}
 
+	err = v.checkMigrationEnums()
+	if err != nil {
+		return nil, fmt.Errorf("invalid migrations: %w", err)
+	}
+
 	htmlPolicies, err := policySet.Resolve()
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve HTML policies: %w", err)
@@ -180,15 +213,20 @@ func (v *Validator) resolveBlockRefs(
 
 func collectBlockDeclarations(
 	dir map[BlockKind]map[string]*BlockConstraint,
-	kind BlockKind, defs []*BlockDefinition,
+	kind BlockKind, setRef specRef, defs []*BlockDefinition,
 ) error {
-	for _, def := range defs {
+	for i, def := range defs {
 		_, exists := dir[kind][def.ID]
 		if exists {
 			return fmt.Errorf("%q has already been declared", def.ID)
 		}
 
-		dir[kind][def.ID] = &def.Block
+		block := def.Block.Copy()
+
+		block.setSpecRef(setRef.child(
+			kindFields[kind], strconv.Itoa(i), "block"))
+
+		dir[kind][def.ID] = block
 	}
 
 	return nil
@@ -218,10 +256,31 @@ func (v *Validator) WithConstraints(
 	}
 
 	nv.variants = v.variants
+	nv.formats = v.formats
 
 	return nv, nil
 }
 
+// WithFormats returns a shallow copy of the Validator that can validate the
+// given custom string formats. The custom formats replace any formats that
+// previously were added to the validator.
+func (v *Validator) WithFormats(formats ...CustomFormat) (*Validator, error) {
+	registry, err := NewFormatRegistry(formats...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom formats: %w", err)
+	}
+
+	nv := *v
+	nv.formats = registry
+
+	return &nv, nil
+}
+
+// Formats returns the custom string formats of the validator.
+func (v *Validator) Formats() *FormatRegistry {
+	return v.formats
+}
+
 type ValidationResult struct {
 	Entity              []EntityRef `json:"entity,omitempty"`
 	Error               string      `json:"error,omitempty"`
@@ -318,6 +377,23 @@ func (er EntityRef) typeDesc() string {
 	return er.Rel
 }
 
+// coverageEnumValidator returns an enum validation function that records
+// the declarations of the values it has validated.
+func (v *Validator) coverageEnumValidator(
+	cov *CoverageCollector,
+) func(enum string, value string) (*Deprecation, error) {
+	return func(enum string, value string) (*Deprecation, error) {
+		depr, err := v.enums.ValidValue(enum, value)
+		if err != nil {
+			return nil, err
+		}
+
+		cov.hit(CoverageKindEnumValue, v.enums.valueSources(enum, value)...)
+
+		return depr, nil
+	}
+}
+
 func (v *Validator) validateHTML(policyName string, value string) error {
 	if policyName == "" {
 		policyName = "default"
@@ -328,7 +404,43 @@ func (v *Validator) validateHTML(policyName string, value string) error {
 		return fmt.Errorf("no %q HTML policy defined", policyName)
 	}
 
-	return policy.Check(value)
+	_, err := policy.CheckDeprecations(value, v.htmlValidationContext())
+
+	return err
+}
+
+// htmlDeprecations returns the deprecated HTML attributes and attribute
+// values that are used in a HTML value. Invalid HTML is reported by
+// validateHTML, and is ignored here.
+func (v *Validator) htmlDeprecations(
+	policyName string, value string,
+) ([]HTMLAttributeDeprecation, error) {
+	if policyName == "" {
+		policyName = "default"
+	}
+
+	policy, ok := v.htmlPolicies[policyName]
+	if !ok || !policy.deprecations {
+		return nil, nil
+	}
+
+	depr, err := policy.CheckDeprecations(value, v.htmlValidationContext())
+	if err != nil {
+		return nil, nil //nolint: nilerr
+	}
+
+	return depr, nil
+}
+
+// htmlValidationContext returns the context used to validate HTML attribute
+// values.
+func (v *Validator) htmlValidationContext() *ValidationContext {
+	return &ValidationContext{
+		coll:         ValueDiscarder{},
+		formats:      v.formats,
+		ValidateHTML: v.validateHTML,
+		ValidateEnum: v.enums.ValidValue,
+	}
 }
 
 type ValidationOptionFunc func(vc *ValidationContext)
@@ -349,6 +461,16 @@ type DeprecationContext struct {
 	Block *newsdoc.Block `json:"block,omitempty"`
 	// Value is provided if this was a value deprecation.
 	Value *string `json:"value,omitempty"`
+	// HTML is provided if this was the deprecation of a HTML attribute,
+	// or attribute value, in a HTML value. Value is set to the value of
+	// the HTML attribute.
+	HTML *HTMLAttributeRef `json:"html,omitempty"`
+}
+
+// HTMLAttributeRef references an attribute of a HTML element.
+type HTMLAttributeRef struct {
+	Element   string `json:"element"`
+	Attribute string `json:"attribute"`
 }
 
 // DeprecationDecision tells revisor how to handle the deprecation.
@@ -386,16 +508,22 @@ func (v *Validator) ValidateDocument(
 	var declared bool
 
 	vCtx := ValidationContext{
-		coll:         ValueDiscarder{},
-		variants:     v.variants,
-		ValidateHTML: v.validateHTML,
-		ValidateEnum: v.enums.ValidValue,
+		coll:             ValueDiscarder{},
+		variants:         v.variants,
+		formats:          v.formats,
+		htmlDeprecations: v.htmlDeprecations,
+		ValidateHTML:     v.validateHTML,
+		ValidateEnum:     v.enums.ValidValue,
 	}
 
 	for i := range opts {
 		opts[i](&vCtx)
 	}
 
+	if vCtx.cov != nil {
+		vCtx.ValidateEnum = v.coverageEnumValidator(vCtx.cov)
+	}
+
 	_, err := uuid.Parse(document.UUID)
 	if err != nil {
 		res = append(res, ValidationResult{
@@ -419,6 +547,8 @@ func (v *Validator) ValidateDocument(
 			declared = true
 		}
 
+		vCtx.cov.hit(CoverageKindDocument, v.documents[i].spec)
+
 		res, err = checkDeprecation(
 			ctx, vCtx, res, document,
 			DeprecationContext{},
@@ -507,6 +637,55 @@ func checkDeprecation(
 	return res, nil
 }
 
+// checkValueDeprecation runs the deprecation handler for the deprecations of
+// a value: the deprecation of the constraint, the deprecation of the value
+// itself, and the deprecations of any HTML attributes used in the value.
+func checkValueDeprecation(
+	ctx context.Context,
+	vCtx ValidationContext,
+	res []ValidationResult,
+	doc *newsdoc.Document,
+	dCtx DeprecationContext,
+	check StringConstraint, value string, valueDepr *Deprecation,
+) ([]ValidationResult, error) {
+	if vCtx.depr == nil {
+		return res, nil
+	}
+
+	res, err := checkDeprecation(ctx, vCtx, res, doc, dCtx,
+		check.Deprecated, valueDepr)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.Format != StringFormatHTML || vCtx.htmlDeprecations == nil {
+		return res, nil
+	}
+
+	htmlDepr, err := vCtx.htmlDeprecations(check.HTMLPolicy, value)
+	if err != nil {
+		return nil, fmt.Errorf("check HTML deprecations: %w", err)
+	}
+
+	for _, hd := range htmlDepr {
+		hCtx := dCtx
+
+		hCtx.HTML = &HTMLAttributeRef{
+			Element:   hd.Element,
+			Attribute: hd.Attribute,
+		}
+		hCtx.Value = &hd.Value
+
+		res, err = checkDeprecation(ctx, vCtx, res, doc, hCtx,
+			&hd.Deprecation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
 func validateDocumentAttributes(
 	ctx context.Context,
 	constraints []ConstraintMap, d *newsdoc.Document,
@@ -535,16 +714,19 @@ func validateDocumentAttributes(
 				// As attributes always exist we only want to
 				// trigger a deprecation warning if they
 				// actually have a value.
-				res, err = checkDeprecation(
+				res, err = checkValueDeprecation(
 					ctx, vCtx, res, d,
 					DeprecationContext{
 						Entity: &ref,
 						Value:  &value,
-					}, depr, check.Deprecated)
+					}, check, value, depr)
 				if err != nil {
 					return nil, err
 				}
 
+				vCtx.cov.hitValue(CoverageKindAttribute,
+					constraints[i], k, check, value)
+
 				vCtx.coll.CollectValue(ValueAnnotation{
 					Ref:   []EntityRef{ref},
 					Value: value,
@@ -624,7 +806,7 @@ func (v *Validator) validateBlockSlice(
 				nilOrLTE(constraint.MaxCount, count)
 			if !valid {
 				res = append(res, ValidationResult{
-					Error: constraint.DescribeCountConstraint(kind),
+					Error: constraint.DescribeCountConstraintWithFormats(kind, v.formats),
 				})
 			}
 		}
@@ -702,6 +884,8 @@ func (v *Validator) validateBlock(
 				defined = true
 			}
 
+			vCtx.cov.hit(CoverageKindBlock, constraint.spec)
+
 			r, err := checkDeprecation(
 				ctx, vCtx, res, doc,
 				DeprecationContext{
@@ -833,16 +1017,19 @@ func validateBlockAttributes(
 				// As attributes always exist we only want to
 				// trigger a deprecation warning if they
 				// actually have a value.
-				res, err = checkDeprecation(
+				res, err = checkValueDeprecation(
 					ctx, vCtx, res, doc, DeprecationContext{
 						Entity: &ref,
 						Block:  b,
 						Value:  &value,
-					}, check.Deprecated, depr)
+					}, check, value, depr)
 				if err != nil {
 					return nil, err
 				}
 
+				vCtx.cov.hitValue(CoverageKindAttribute,
+					constraints[i], k, check, value)
+
 				vCtx.coll.CollectValue(ValueAnnotation{
 					Ref:        []EntityRef{ref},
 					Constraint: check,
@@ -924,19 +1111,22 @@ func validateBlockData(
 				})
 			}
 
-			r, err := checkDeprecation(
+			r, err := checkValueDeprecation(
 				ctx, vCtx, res, doc,
 				DeprecationContext{
 					Entity: &ref,
 					Block:  b,
 					Value:  &v,
-				}, check.Deprecated, depr)
+				}, check, v, depr)
 			if err != nil {
 				return nil, err
 			}
 
 			res = r
 
+			vCtx.cov.hitValue(CoverageKindData,
+				constraints[i], k, check, v)
+
 			vCtx.coll.CollectValue(ValueAnnotation{
 				Ref:        []EntityRef{ref},
 				Constraint: check,
@@ -979,63 +1169,80 @@ type BlockConstraintSet interface {
 type Deprecation struct {
 	Label string `json:"label"`
 	Doc   string `json:"doc"`
+	// Replacement tells users what to use instead of the deprecated
+	// entity.
+	Replacement *DeprecationReplacement `json:"replacement,omitempty"`
+	// Sunset is the time after which the deprecation should be enforced,
+	// see SunsetDeprecationHandler.
+	Sunset *time.Time `json:"sunset,omitempty"`
 }
 
 // DecodeConstraintSetsFS decodes a set of constraints from a embedded
-// filesystem.
+// filesystem. See LoadConstraintSets for loading from any fs.FS.
 func DecodeConstraintSetsFS(
 	sFS embed.FS, names ...string,
 ) ([]ConstraintSet, error) {
-	var constraints []ConstraintSet
-
-	for _, n := range names {
-		data, err := sFS.ReadFile(n)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"load constraints from %q: %w",
-				n, err)
-		}
-
-		var c ConstraintSet
-
-		err = decodeBytes(data, &c)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"parse constraints in %q: %w",
-				n, err)
-		}
-
-		constraints = append(constraints, c)
-	}
-
-	return constraints, nil
-}
-
-func decodeBytes(data []byte, o interface{}) error {
-	dec := json.NewDecoder(bytes.NewReader(data))
-	dec.DisallowUnknownFields()
-
-	err := dec.Decode(o)
-	if err != nil {
-		return fmt.Errorf("invalid JSON: %w", err)
-	}
-
-	return nil
+	return LoadConstraintSets(sFS, names...)
 }
 
 type ConstraintSet struct {
-	Version      int                  `json:"version,omitempty"`
-	Schema       string               `json:"$schema,omitempty"`
-	Name         string               `json:"name"`
+	Version int    `json:"version,omitempty"`
+	Schema  string `json:"$schema,omitempty"`
+	Name    string `json:"name"`
+	// Imports lists the names of the constraint sets that this set
+	// depends on. Sets that declare imports may only reference
+	// documents, blocks, enums and HTML policies from themselves and the
+	// sets they import.
+	Imports      []string             `json:"imports,omitempty"`
 	Documents    []DocumentConstraint `json:"documents,omitempty"`
 	Links        []*BlockDefinition   `json:"links,omitempty"`
 	Meta         []*BlockDefinition   `json:"meta,omitempty"`
 	Content      []*BlockDefinition   `json:"content,omitempty"`
 	Enums        []Enum               `json:"enums,omitempty"`
 	HTMLPolicies []HTMLPolicy         `json:"htmlPolicies,omitempty"`
+	// Migrations rewrite documents that use deprecated parts of the
+	// specification, see Validator.Migrate.
+	Migrations []DocumentMigration `json:"migrations,omitempty"`
+}
+
+// BlockDefinitions returns the block definitions of the given kind.
+func (cs ConstraintSet) BlockDefinitions(kind BlockKind) []*BlockDefinition {
+	switch kind {
+	case BlockKindLink:
+		return cs.Links
+	case BlockKindMeta:
+		return cs.Meta
+	case BlockKindContent:
+		return cs.Content
+	}
+
+	return nil
 }
 
 func (cs ConstraintSet) Validate() error {
+	switch {
+	case cs.Version < 0:
+		return fmt.Errorf("invalid format version %d", cs.Version)
+	case cs.Version > CurrentFormatVersion:
+		return fmt.Errorf(
+			"format version %d is newer than the supported version %d, revisor needs to be upgraded",
+			cs.Version, CurrentFormatVersion)
+	case cs.Version != 0 && cs.Version < CurrentFormatVersion:
+		return fmt.Errorf(
+			"format version %d is outdated, the current version is %d, use \"revisor migrate\" to upgrade",
+			cs.Version, CurrentFormatVersion)
+	}
+
+	for _, name := range cs.Imports {
+		if name == "" {
+			return errors.New("imports must not be empty")
+		}
+
+		if name == cs.Name {
+			return errors.New("a constraint set cannot import itself")
+		}
+	}
+
 	err := validateBlockDeclarations(BlockKindLink, cs.Links)
 	if err != nil {
 		return err
@@ -1062,6 +1269,11 @@ func (cs ConstraintSet) Validate() error {
 		}
 	}
 
+	err = validateMigrations(cs.Migrations)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
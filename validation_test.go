@@ -277,6 +277,13 @@ func TestValidateDocument(t *testing.T) {
 		"testdata/constraints/labels-hints.json",
 		"testdata/constraints/transcript.json",
 		"testdata/constraints/colour.json",
+		"testdata/constraints/language.json",
+		"testdata/constraints/url.json",
+		"testdata/constraints/iso8601.json",
+		"testdata/constraints/codes.json",
+		"testdata/constraints/contact.json",
+		"testdata/constraints/geometry.json",
+		"testdata/constraints/json.json",
 	)
 
 	testValidator, err := revisor.NewValidator(testConstraints...)
@@ -0,0 +1,303 @@
+package revisor
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ValidatorHandle holds a validator that is rebuilt when the constraint sets
+// that it was loaded from change. If a new validator fails to build the old
+// validator is kept and the error is reported.
+type ValidatorHandle struct {
+	fsys     fs.FS
+	pattern  string
+	interval time.Duration
+	variants []Variant
+	formats  []CustomFormat
+	onError  func(err error)
+	onReload func(v *Validator)
+
+	current atomic.Pointer[Validator]
+
+	m           sync.Mutex
+	fingerprint [sha256.Size]byte
+	failed      [sha256.Size]byte
+	readErr     string
+	lastErr     error
+}
+
+// ValidatorHandleOption is used to configure a ValidatorHandle.
+type ValidatorHandleOption func(h *ValidatorHandle)
+
+// WithReloadInterval sets how often the constraint sets are checked for
+// changes by Run, defaults to 10 seconds.
+func WithReloadInterval(d time.Duration) ValidatorHandleOption {
+	return func(h *ValidatorHandle) {
+		h.interval = d
+	}
+}
+
+// WithConstraintSetPattern sets the glob pattern (as used by fs.Glob) that is
+// used to find constraint set files. Defaults to all the *.json, *.yaml and
+// *.yml files in the root of the filesystem. The files are loaded in lexical
+// order.
+func WithConstraintSetPattern(pattern string) ValidatorHandleOption {
+	return func(h *ValidatorHandle) {
+		h.pattern = pattern
+	}
+}
+
+// WithHandleVariants sets the variants of the validators created by the
+// handle.
+func WithHandleVariants(variants ...Variant) ValidatorHandleOption {
+	return func(h *ValidatorHandle) {
+		h.variants = variants
+	}
+}
+
+// WithHandleFormats sets the custom string formats of the validators created
+// by the handle.
+func WithHandleFormats(formats ...CustomFormat) ValidatorHandleOption {
+	return func(h *ValidatorHandle) {
+		h.formats = formats
+	}
+}
+
+// WithReloadErrorHandler sets a function that is called when the constraint
+// sets have changed but a new validator couldn't be created.
+func WithReloadErrorHandler(fn func(err error)) ValidatorHandleOption {
+	return func(h *ValidatorHandle) {
+		h.onError = fn
+	}
+}
+
+// WithReloadHandler sets a function that is called when a new validator has
+// been swapped in.
+func WithReloadHandler(fn func(v *Validator)) ValidatorHandleOption {
+	return func(h *ValidatorHandle) {
+		h.onReload = fn
+	}
+}
+
+// NewValidatorHandle creates a handle for a validator that is loaded from the
+// constraint sets in the filesystem. The initial load must succeed.
+func NewValidatorHandle(
+	fsys fs.FS, opts ...ValidatorHandleOption,
+) (*ValidatorHandle, error) {
+	h := ValidatorHandle{
+		fsys:     fsys,
+		interval: 10 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	_, err := h.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+// NewValidatorHandleFromDir creates a handle for a validator that is loaded
+// from the constraint sets in a directory.
+func NewValidatorHandleFromDir(
+	dir string, opts ...ValidatorHandleOption,
+) (*ValidatorHandle, error) {
+	return NewValidatorHandle(os.DirFS(dir), opts...)
+}
+
+// Validator returns the current validator. Use the same validator for all
+// the steps of a validation to get a consistent result.
+func (h *ValidatorHandle) Validator() *Validator {
+	return h.current.Load()
+}
+
+// LastError returns the error from the last reload, or nil if it succeeded.
+func (h *ValidatorHandle) LastError() error {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	return h.lastErr
+}
+
+// Reload checks if the constraint sets have changed and swaps in a new
+// validator if they have. Returns true if a new validator was swapped in. The
+// error handler is called once for every failed version of the constraint
+// sets.
+func (h *ValidatorHandle) Reload() (bool, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	names, contents, fingerprint, err := h.read()
+	if err != nil {
+		// Read errors have no fingerprint, so they are deduplicated
+		// by their message instead.
+		if h.readErr == err.Error() {
+			return false, h.lastErr
+		}
+
+		h.readErr = err.Error()
+		h.failed = [sha256.Size]byte{}
+
+		return false, h.failure(err)
+	}
+
+	h.readErr = ""
+
+	if h.current.Load() != nil && fingerprint == h.fingerprint {
+		h.lastErr = nil
+
+		return false, nil
+	}
+
+	if h.lastErr != nil && fingerprint == h.failed {
+		return false, h.lastErr
+	}
+
+	v, err := h.build(names, contents)
+	if err != nil {
+		h.failed = fingerprint
+
+		return false, h.failure(err)
+	}
+
+	h.fingerprint = fingerprint
+	h.lastErr = nil
+	h.current.Store(v)
+
+	if h.onReload != nil {
+		h.onReload(v)
+	}
+
+	return true, nil
+}
+
+func (h *ValidatorHandle) failure(err error) error {
+	h.lastErr = err
+
+	if h.onError != nil {
+		h.onError(err)
+	}
+
+	return err
+}
+
+// list returns the names of the constraint set files.
+func (h *ValidatorHandle) list() ([]string, error) {
+	if h.pattern != "" {
+		return fs.Glob(h.fsys, h.pattern) //nolint:wrapcheck
+	}
+
+	entries, err := fs.ReadDir(h.fsys, ".")
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var names []string
+
+	for _, e := range entries {
+		if e.IsDir() || !isConstraintSetFile(e.Name()) {
+			continue
+		}
+
+		names = append(names, e.Name())
+	}
+
+	return names, nil
+}
+
+// read reads the constraint set files and calculates a fingerprint for their
+// names and contents.
+func (h *ValidatorHandle) read() ([]string, [][]byte, [sha256.Size]byte, error) {
+	var fingerprint [sha256.Size]byte
+
+	names, err := h.list()
+	if err != nil {
+		return nil, nil, fingerprint, fmt.Errorf("list constraint sets: %w", err)
+	}
+
+	if len(names) == 0 {
+		return nil, nil, fingerprint, errors.New("no constraint sets found")
+	}
+
+	slices.Sort(names)
+
+	hash := sha256.New()
+	contents := make([][]byte, len(names))
+
+	for i, name := range names {
+		data, err := fs.ReadFile(h.fsys, name)
+		if err != nil {
+			return nil, nil, fingerprint, fmt.Errorf(
+				"read constraint set %q: %w", name, err)
+		}
+
+		contents[i] = data
+
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00", name, len(data))
+		_, _ = hash.Write(data)
+	}
+
+	hash.Sum(fingerprint[:0])
+
+	return names, contents, fingerprint, nil
+}
+
+func (h *ValidatorHandle) build(names []string, contents [][]byte) (*Validator, error) {
+	sets := make([]ConstraintSet, len(names))
+
+	for i, name := range names {
+		cs, err := decodeConstraintSetFile(name, contents[i])
+		if err != nil {
+			return nil, err
+		}
+
+		sets[i] = cs
+	}
+
+	v, err := NewValidator(sets...)
+	if err != nil {
+		return nil, fmt.Errorf("create validator: %w", err)
+	}
+
+	if len(h.variants) > 0 {
+		v = v.WithVariants(h.variants...)
+	}
+
+	if len(h.formats) > 0 {
+		v, err = v.WithFormats(h.formats...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// Run checks for changes to the constraint sets until the context is
+// cancelled. Reload errors are reported through the reload error handler and
+// LastError(), they don't stop Run.
+func (h *ValidatorHandle) Run(ctx context.Context) error {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		case <-ticker.C:
+			_, _ = h.Reload()
+		}
+	}
+}
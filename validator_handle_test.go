@@ -0,0 +1,196 @@
+package revisor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/ttab/newsdoc"
+	"github.com/ttab/revisor"
+)
+
+const handleSpecA = `{
+  "version": 1,
+  "name": "handle",
+  "documents": [{"declares": "test/a"}]
+}`
+
+const handleSpecB = `{
+  "version": 1,
+  "name": "handle",
+  "documents": [{"declares": "test/b"}]
+}`
+
+func handleAccepts(t *testing.T, v *revisor.Validator, docType string) bool {
+	t.Helper()
+
+	res, err := v.ValidateDocument(context.Background(), &newsdoc.Document{
+		UUID: "4b7cb2d9-0af0-4bc0-bd54-5ef7e1a8bb2e",
+		Type: docType,
+	})
+	mustf(t, err, "validate %q document", docType)
+
+	return len(res) == 0
+}
+
+func TestValidatorHandle(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.json": {Data: []byte(handleSpecA)},
+	}
+
+	var (
+		reloads int
+		errs    []error
+	)
+
+	h, err := revisor.NewValidatorHandle(fsys,
+		revisor.WithReloadHandler(func(_ *revisor.Validator) {
+			reloads++
+		}),
+		revisor.WithReloadErrorHandler(func(err error) {
+			errs = append(errs, err)
+		}))
+	mustf(t, err, "create handle")
+
+	first := h.Validator()
+
+	if !handleAccepts(t, first, "test/a") {
+		t.Fatal("expected the initial validator to accept test/a")
+	}
+
+	changed, err := h.Reload()
+	mustf(t, err, "reload unchanged constraint sets")
+
+	if changed || h.Validator() != first {
+		t.Fatal("expected unchanged constraint sets to keep the validator")
+	}
+
+	fsys["a.json"] = &fstest.MapFile{Data: []byte(handleSpecB)}
+
+	changed, err = h.Reload()
+	mustf(t, err, "reload changed constraint sets")
+
+	second := h.Validator()
+
+	if !changed || second == first {
+		t.Fatal("expected a new validator to be swapped in")
+	}
+
+	if handleAccepts(t, second, "test/a") || !handleAccepts(t, second, "test/b") {
+		t.Fatal("expected the new validator to use the new constraint sets")
+	}
+
+	if !handleAccepts(t, first, "test/a") {
+		t.Fatal("expected the old validator snapshot to be unaffected")
+	}
+
+	fsys["a.json"] = &fstest.MapFile{Data: []byte(`{"version": 1, "nope": true}`)}
+
+	for range 2 {
+		changed, err = h.Reload()
+		if err == nil {
+			t.Fatal("expected reload of invalid constraint sets to fail")
+		}
+
+		if changed || h.Validator() != second {
+			t.Fatal("expected the old validator to be kept after a failed reload")
+		}
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("expected the error handler to be called once, got %d calls", len(errs))
+	}
+
+	if !errors.Is(h.LastError(), errs[0]) {
+		t.Fatalf("expected LastError to return the reload error, got %v", h.LastError())
+	}
+
+	delete(fsys, "a.json")
+
+	for range 2 {
+		_, err = h.Reload()
+		if err == nil {
+			t.Fatal("expected reload without constraint sets to fail")
+		}
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected read errors to be reported once, got %d calls", len(errs))
+	}
+
+	fsys["a.json"] = &fstest.MapFile{Data: []byte(handleSpecA)}
+
+	changed, err = h.Reload()
+	mustf(t, err, "reload fixed constraint sets")
+
+	if !changed || h.LastError() != nil {
+		t.Fatal("expected the fixed constraint sets to be loaded")
+	}
+
+	if reloads != 3 {
+		t.Fatalf("expected 3 calls to the reload handler, got %d", reloads)
+	}
+}
+
+func TestValidatorHandleYAML(t *testing.T) {
+	h, err := revisor.NewValidatorHandle(fstest.MapFS{
+		"a.json":   {Data: []byte(handleSpecA)},
+		"b.yaml":   {Data: []byte("version: 1\nname: b\ndocuments:\n  - declares: test/b\n")},
+		"notes.md": {Data: []byte("not a constraint set")},
+	})
+	mustf(t, err, "create handle")
+
+	if !handleAccepts(t, h.Validator(), "test/a") ||
+		!handleAccepts(t, h.Validator(), "test/b") {
+		t.Fatal("expected both the JSON and YAML constraint sets to be loaded")
+	}
+}
+
+func TestValidatorHandleInitialFailure(t *testing.T) {
+	_, err := revisor.NewValidatorHandle(fstest.MapFS{})
+	if err == nil {
+		t.Fatal("expected an error when there are no constraint sets")
+	}
+
+	_, err = revisor.NewValidatorHandle(fstest.MapFS{
+		"a.json": {Data: []byte(`{"documents": [{"declares": "test/a", "bad": 1}]}`)},
+	})
+	if err == nil {
+		t.Fatal("expected an error for invalid constraint sets")
+	}
+}
+
+func TestValidatorHandleRun(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.json": {Data: []byte(handleSpecA)},
+	}
+
+	h, err := revisor.NewValidatorHandle(fsys,
+		revisor.WithReloadInterval(5*time.Millisecond))
+	mustf(t, err, "create handle")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsys["b.json"] = &fstest.MapFile{Data: []byte(`{
+  "version": 1,
+  "name": "other",
+  "documents": [{"declares": "test/c"}]
+}`)}
+
+	go func() {
+		_ = h.Run(ctx)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !handleAccepts(t, h.Validator(), "test/c") {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the validator to be reloaded")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -55,7 +55,7 @@ func errCoordMismatch(want string, got geometry.CoordinateType) error {
 		gotName, want)
 }
 
-func validateWKT(spec string, value string) error {
+func validateWKT(value string, sc *StringConstraint) error {
 	parser := parser.New()
 
 	geo, err := parser.ParseWKT(strings.NewReader(value))
@@ -63,6 +63,17 @@ func validateWKT(spec string, value string) error {
 		return fmt.Errorf("failed to parse: %w", err)
 	}
 
+	err = checkGeometryType(sc.Geometry, geo)
+	if err != nil {
+		return err
+	}
+
+	return checkGeometry(geo, sc)
+}
+
+// checkGeometryType checks that the geometry matches the geometry spec, f.ex.
+// "polygon-z".
+func checkGeometryType(spec string, geo geometry.Geometry) error {
 	g, coord, _ := strings.Cut(spec, "-")
 
 	ct, ok := coords[coord]
@@ -0,0 +1,48 @@
+package revisor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ttab/revisor/internal"
+)
+
+// DecodeConstraintSetYAML strictly decodes a constraint set from YAML. The
+// YAML is decoded with the same rules as JSON constraint sets, and errors
+// refer to the line and column in the YAML source. The name is used to
+// identify the source in errors.
+func DecodeConstraintSetYAML(name string, r io.Reader) (ConstraintSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ConstraintSet{}, &DecodeError{
+			Name: name,
+			Err:  fmt.Errorf("read constraint set: %w", err),
+		}
+	}
+
+	return decodeConstraintSetYAML(name, data)
+}
+
+func decodeConstraintSetYAML(name string, data []byte) (ConstraintSet, error) {
+	jsonData, positions, err := internal.YAMLToJSON(data)
+	if err != nil {
+		return ConstraintSet{}, &DecodeError{
+			Name: name,
+			Err:  err,
+		}
+	}
+
+	return decodeConstraintSetJSON(name, jsonData, func(offset int64) (int, int) {
+		// Find the last YAML value that starts at or before the offset.
+		i := sort.Search(len(positions), func(i int) bool {
+			return positions[i].Offset > offset
+		})
+
+		if i == 0 {
+			return 0, 0
+		}
+
+		return positions[i-1].Line, positions[i-1].Column
+	})
+}
@@ -0,0 +1,211 @@
+package revisor_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ttab/revisor"
+	"github.com/ttab/revisor/internal"
+)
+
+func TestDecodeConstraintSetYAML(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("internal", "revisorschemas", "*.json"))
+	mustf(t, err, "glob for constraint sets")
+
+	testPaths, err := filepath.Glob(filepath.Join("testdata", "constraints", "*.json"))
+	mustf(t, err, "glob for test constraint sets")
+
+	for _, p := range append(paths, testPaths...) {
+		t.Run(p, func(t *testing.T) {
+			data, err := os.ReadFile(p)
+			mustf(t, err, "read constraint set")
+
+			want, err := revisor.DecodeConstraintSet(p, bytes.NewReader(data))
+			mustf(t, err, "decode JSON constraint set")
+
+			yamlData, err := internal.JSONToYAML(data)
+			mustf(t, err, "convert to YAML")
+
+			got, err := revisor.DecodeConstraintSetYAML(p, bytes.NewReader(yamlData))
+			mustf(t, err, "decode YAML constraint set")
+
+			wantJSON, err := json.Marshal(want)
+			mustf(t, err, "marshal JSON constraint set")
+
+			gotJSON, err := json.Marshal(got)
+			mustf(t, err, "marshal YAML constraint set")
+
+			if diff := cmp.Diff(string(wantJSON), string(gotJSON)); diff != "" {
+				t.Fatalf("YAML constraint set mismatch (-want +got):\n%s", diff)
+			}
+
+			roundTrip, _, err := internal.YAMLToJSON(yamlData)
+			mustf(t, err, "convert back to JSON")
+
+			var compact bytes.Buffer
+
+			mustf(t, json.Compact(&compact, data), "compact JSON")
+
+			if diff := cmp.Diff(compact.String(), string(roundTrip)); diff != "" {
+				t.Fatalf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadConstraintSetsYAML(t *testing.T) {
+	fsys := fstest.MapFS{
+		"specs/a.yaml": {Data: []byte(`
+# Comments are allowed in YAML.
+version: 1
+name: a
+documents:
+  - declares: test/doc
+    attributes:
+      title: &text
+        allowEmpty: true
+        pattern: "^[A-Z]"
+    meta:
+      - declares: {type: test/meta}
+        data:
+          headline: *text
+          date:
+            format: RFC3339
+          label:
+            <<: *text
+            glob: ["a*"]
+`)},
+		"specs/b.json": {Data: []byte(`{"name": "b"}`)},
+	}
+
+	sets, err := revisor.GlobConstraintSets(fsys, "specs/*")
+	mustf(t, err, "load constraint sets")
+
+	assertSetNames(t, sets, "a", "b")
+
+	v, err := revisor.NewValidator(sets...)
+	mustf(t, err, "create validator")
+
+	spec, ok := v.DocumentSpec("test/doc")
+	if !ok {
+		t.Fatal("expected test/doc to be declared")
+	}
+
+	meta := spec.Meta[0]
+
+	label := meta.Data.Constraints["label"]
+
+	if !label.AllowEmpty || label.Pattern.String() != "^[A-Z]" ||
+		label.Glob.String() != `must match "a*"` {
+		t.Fatalf("expected the merge key to be resolved, got %#v", label)
+	}
+
+	if meta.Data.Constraints["headline"].Pattern.String() != "^[A-Z]" {
+		t.Fatal("expected the alias to be resolved")
+	}
+}
+
+func TestDecodeConstraintSetYAMLErrors(t *testing.T) {
+	cases := map[string]struct {
+		Source string
+		Line   int
+		Column int
+		Error  string
+	}{
+		"unknown field": {
+			Source: "name: x\ndocuments:\n  - declares: test/doc\n    atributes: {}\n",
+			Line:   4,
+			Column: 5,
+			Error:  `unknown field "atributes"`,
+		},
+		"nested unknown field": {
+			Source: "name: x\ndocuments:\n  - declares: test/doc\n    attributes:\n      title:\n        optinal: true\n",
+			Line:   6,
+			Column: 9,
+			Error:  `unknown field "optinal"`,
+		},
+		"type": {
+			Source: "name: x\nversion: one\n",
+			Line:   2,
+			Column: 10,
+			Error:  "cannot unmarshal string",
+		},
+		"duplicate key": {
+			Source: "name: x\nname: y\n",
+			Error:  `line 2: mapping key "name" already defined`,
+		},
+		"multiple documents": {
+			Source: "name: x\n---\nname: y\n",
+			Error:  "only one YAML document",
+		},
+		"syntax": {
+			Source: "name: x\n  bad: [\n",
+			Error:  "invalid YAML",
+		},
+		"invalid pattern": {
+			Source: "name: x\ndocuments:\n  - declares: test/doc\n    attributes:\n      title:\n        pattern: \"[\"\n",
+			Error:  "missing closing ]",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := revisor.DecodeConstraintSetYAML("spec.yaml",
+				strings.NewReader(tc.Source))
+			if err == nil {
+				t.Fatal("expected decoding to fail")
+			}
+
+			var de *revisor.DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("expected a DecodeError, got %T: %v", err, err)
+			}
+
+			if de.Line != tc.Line || de.Column != tc.Column {
+				t.Errorf("expected the error to be at %d:%d, got %d:%d",
+					tc.Line, tc.Column, de.Line, de.Column)
+			}
+
+			if !strings.HasPrefix(err.Error(), "spec.yaml:") ||
+				!strings.Contains(err.Error(), tc.Error) {
+				t.Errorf("unexpected error message: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeConstraintSetYAMLAliasExpansion(t *testing.T) {
+	// A "billion laughs" document, every level multiplies the size of the
+	// expanded document by nine.
+	source := `name: laughs
+a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+
+	start := time.Now()
+
+	_, err := revisor.DecodeConstraintSetYAML("laughs.yaml",
+		strings.NewReader(source))
+	if err == nil || !strings.Contains(err.Error(), "expands to too much data") {
+		t.Fatalf("expected alias expansion to be rejected, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the expansion to be stopped early, took %v", elapsed)
+	}
+}